@@ -18,18 +18,47 @@ import (
 
 var appFlags = []cli.Flag{
 	cmd.VanguardGRPCEndpoint,
+	cmd.VanguardTLSCACertFlag,
+	cmd.VanguardTLSClientCertFlag,
+	cmd.VanguardTLSClientKeyFlag,
+	cmd.VanguardBearerTokenFlag,
+	cmd.VanguardGRPCMaxMsgSizeFlag,
+	cmd.VanguardGRPCRetriesFlag,
+	cmd.VanguardGRPCRetryDelayFlag,
 	cmd.PandoraRPCEndpoint,
+	cmd.PandoraQuorumFlag,
+	cmd.ReconnectMaxIntervalFlag,
+	cmd.ReconnectMaxAttemptsFlag,
+	cmd.RecordStreamsFlag,
 	cmd.VerbosityFlag,
 	cmd.IPCPathFlag,
 	cmd.HTTPEnabledFlag,
 	cmd.HTTPListenAddrFlag,
 	cmd.HTTPPortFlag,
+	cmd.HTTPCORSDomainFlag,
+	cmd.HTTPVirtualHostsFlag,
+	cmd.HTTPApiFlag,
+	cmd.HTTPPathPrefixFlag,
+	cmd.HTTPReadTimeoutFlag,
+	cmd.HTTPWriteTimeoutFlag,
+	cmd.HTTPIdleTimeoutFlag,
 	cmd.WSEnabledFlag,
 	cmd.WSListenAddrFlag,
 	cmd.WSPortFlag,
+	cmd.WSAllowedOriginsFlag,
+	cmd.WSApiFlag,
+	cmd.WSPathPrefixFlag,
+	cmd.AuthRPCJWTSecretFlag,
+	cmd.RPCTLSCertFlag,
+	cmd.RPCTLSKeyFlag,
+	cmd.RPCTLSClientCAFlag,
+	cmd.MonitoringHostFlag,
+	cmd.MonitoringPortFlag,
+	cmd.DisableMonitoringFlag,
 	cmd.DataDirFlag,
 	cmd.ClearDB,
 	cmd.ForceClearDB,
+	cmd.EphemeralFlag,
 	cmd.LogFileName,
 	cmd.LogFormat,
 }
@@ -45,6 +45,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.VerbosityFlag,
 			cmd.ForceClearDB,
 			cmd.ClearDB,
+			cmd.EphemeralFlag,
 			cmd.BoltMMapInitialSizeFlag,
 		},
 	},
@@ -55,11 +56,39 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.HTTPEnabledFlag,
 			cmd.HTTPListenAddrFlag,
 			cmd.HTTPPortFlag,
+			cmd.HTTPCORSDomainFlag,
+			cmd.HTTPVirtualHostsFlag,
+			cmd.HTTPApiFlag,
+			cmd.HTTPPathPrefixFlag,
+			cmd.HTTPReadTimeoutFlag,
+			cmd.HTTPWriteTimeoutFlag,
+			cmd.HTTPIdleTimeoutFlag,
 			cmd.WSEnabledFlag,
 			cmd.WSListenAddrFlag,
 			cmd.WSPortFlag,
+			cmd.WSAllowedOriginsFlag,
+			cmd.WSApiFlag,
+			cmd.WSPathPrefixFlag,
+			cmd.AuthRPCJWTSecretFlag,
+			cmd.RPCTLSCertFlag,
+			cmd.RPCTLSKeyFlag,
+			cmd.RPCTLSClientCAFlag,
+			cmd.MonitoringHostFlag,
+			cmd.MonitoringPortFlag,
+			cmd.DisableMonitoringFlag,
 			cmd.VanguardGRPCEndpoint,
+			cmd.VanguardTLSCACertFlag,
+			cmd.VanguardTLSClientCertFlag,
+			cmd.VanguardTLSClientKeyFlag,
+			cmd.VanguardBearerTokenFlag,
+			cmd.VanguardGRPCMaxMsgSizeFlag,
+			cmd.VanguardGRPCRetriesFlag,
+			cmd.VanguardGRPCRetryDelayFlag,
 			cmd.PandoraRPCEndpoint,
+			cmd.PandoraQuorumFlag,
+			cmd.ReconnectMaxIntervalFlag,
+			cmd.ReconnectMaxAttemptsFlag,
+			cmd.RecordStreamsFlag,
 		},
 	},
 	{
@@ -10,6 +10,7 @@ type PandoraHeaderCache interface {
 	Put(ctx context.Context, slot uint64, header *eth1Types.Header) error
 	Get(ctx context.Context, slot uint64) (*eth1Types.Header, error)
 	GetAll() ([]*eth1Types.Header, error)
+	Slots() []uint64
 	Remove(ctx context.Context, slot uint64)
 	Purge()
 }
@@ -18,6 +19,7 @@ type PandoraHeaderCache interface {
 type VanguardShardInfoCache interface {
 	Put(ctx context.Context, slot uint64, shardInfo *types.VanguardShardInfo) error
 	Get(ctx context.Context, slot uint64) (*types.VanguardShardInfo, error)
+	Slots() []uint64
 	Remove(ctx context.Context, slot uint64)
 	Purge()
 }
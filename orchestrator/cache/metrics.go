@@ -0,0 +1,17 @@
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	panHeaderCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "orchestrator_pandora_pending_header_cache_size",
+		Help: "Number of pandora headers waiting in the pending cache for their vanguard counterpart",
+	})
+	vanShardInfoCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "orchestrator_vanguard_pending_shard_cache_size",
+		Help: "Number of vanguard sharding infos waiting in the pending cache for their pandora counterpart",
+	})
+)
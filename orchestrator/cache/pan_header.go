@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
@@ -30,6 +31,7 @@ func NewPanHeaderCache() *PanHeaderCache {
 func (c *PanHeaderCache) Put(ctx context.Context, slot uint64, header *eth1Types.Header) error {
 	copyHeader := types.CopyHeader(header)
 	c.cache.Add(slot, copyHeader)
+	panHeaderCacheSize.Set(float64(c.cache.Len()))
 	return nil
 }
 
@@ -51,6 +53,7 @@ func (c *PanHeaderCache) Remove(ctx context.Context, slot uint64) {
 			c.cache.Remove(i)
 		}
 	}
+	panHeaderCacheSize.Set(float64(c.cache.Len()))
 }
 
 func (c *PanHeaderCache) GetAll() ([]*eth1Types.Header, error) {
@@ -69,9 +72,25 @@ func (c *PanHeaderCache) GetAll() ([]*eth1Types.Header, error) {
 	return pendingHeaders, nil
 }
 
+// Slots returns the slots of the cached headers in ascending order.
+func (c *PanHeaderCache) Slots() []uint64 {
+	return sortedSlots(c.cache.Keys())
+}
+
 // Clear the pandora header cache.
 func (c *PanHeaderCache) Purge() {
 	c.lock.Lock()
 	c.cache.Purge()
 	c.lock.Unlock()
+	panHeaderCacheSize.Set(0)
+}
+
+// sortedSlots converts the slot keys of a cache and sorts them in ascending order.
+func sortedSlots(keys []interface{}) []uint64 {
+	slots := make([]uint64, len(keys))
+	for i, key := range keys {
+		slots[i] = key.(uint64)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+	return slots
 }
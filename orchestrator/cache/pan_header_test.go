@@ -6,6 +6,7 @@ import (
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"math/rand"
 	"testing"
 )
@@ -101,6 +102,21 @@ func Test_PandoraHeaderGetAll(t *testing.T) {
 	assert.Equal(t, len(expectedPanHeaders), len(actualPanHeaders))
 }
 
+func Test_PandoraHeaderSlots(t *testing.T) {
+	maxCacheSize = 1 << 10
+	pc := NewPanHeaderCache()
+	ctx := context.Background()
+	setup(10)
+
+	for _, slot := range []uint64{7, 2, 9, 4} {
+		pc.Put(ctx, slot, expectedPanHeaders[slot])
+	}
+	assert.DeepEqual(t, []uint64{2, 4, 7, 9}, pc.Slots())
+
+	pc.Remove(ctx, 4)
+	assert.DeepEqual(t, []uint64{7, 9}, pc.Slots())
+}
+
 func Test_PandoraHeaderPurge(t *testing.T) {
 	maxCacheSize = 1 << 10
 	pc := NewPanHeaderCache()
@@ -116,3 +132,22 @@ func Test_PandoraHeaderPurge(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 0, len(actualPanHeaders))
 }
+
+func Test_PandoraHeaderCache_SizeMetric(t *testing.T) {
+	maxCacheSize = 1 << 10
+	pc := NewPanHeaderCache()
+	ctx := context.Background()
+	setup(10)
+
+	for slot := 1; slot <= 10; slot++ {
+		slotUint64 := uint64(slot)
+		pc.Put(ctx, slotUint64, expectedPanHeaders[slotUint64])
+	}
+	assert.Equal(t, float64(10), promtestutil.ToFloat64(panHeaderCacheSize))
+
+	pc.Remove(ctx, 4)
+	assert.Equal(t, float64(6), promtestutil.ToFloat64(panHeaderCacheSize))
+
+	pc.Purge()
+	assert.Equal(t, float64(0), promtestutil.ToFloat64(panHeaderCacheSize))
+}
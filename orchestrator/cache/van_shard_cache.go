@@ -27,6 +27,7 @@ func NewVanShardInfoCache(cacheSize int) *VanShardingInfoCache {
 // Put puts sharding info into a lru cache. return error if fails.
 func (vc *VanShardingInfoCache) Put(ctx context.Context, slot uint64, shardInfo *types.VanguardShardInfo) error {
 	vc.cache.Add(slot, shardInfo)
+	vanShardInfoCacheSize.Set(float64(vc.cache.Len()))
 	return nil
 }
 
@@ -47,6 +48,12 @@ func (vc *VanShardingInfoCache) Remove(ctx context.Context, slot uint64) {
 			vc.cache.Remove(i)
 		}
 	}
+	vanShardInfoCacheSize.Set(float64(vc.cache.Len()))
+}
+
+// Slots returns the slots of the cached sharding infos in ascending order.
+func (vc *VanShardingInfoCache) Slots() []uint64 {
+	return sortedSlots(vc.cache.Keys())
 }
 
 // Clear the vanguard sharding cache.
@@ -54,4 +61,5 @@ func (c *VanShardingInfoCache) Purge() {
 	c.lock.Lock()
 	c.cache.Purge()
 	c.lock.Unlock()
+	vanShardInfoCacheSize.Set(0)
 }
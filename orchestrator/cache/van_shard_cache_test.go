@@ -154,3 +154,18 @@ func TestVanguardRemoveShardInfo(t *testing.T) {
 		assert.DeepEqual(t, generatedShardInfos[uint64(i)], actualHeader)
 	}
 }
+
+func TestVanguardShardingInfoCacheSlots(t *testing.T) {
+	vanguardCache := NewVanShardInfoCache(1 << 10)
+	ctx := context.Background()
+	generatedShardInfos, err := setupShardingCache(10)
+	require.NoError(t, err)
+
+	for _, slot := range []uint64{5, 1, 8} {
+		require.NoError(t, vanguardCache.Put(ctx, slot, generatedShardInfos[slot]))
+	}
+	assert.DeepEqual(t, []uint64{1, 5, 8}, vanguardCache.Slots())
+
+	vanguardCache.Purge()
+	assert.Equal(t, 0, len(vanguardCache.Slots()))
+}
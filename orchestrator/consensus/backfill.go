@@ -0,0 +1,118 @@
+package consensus
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+var (
+	// backfillGracePeriod is the time a slot waits for the other chain before its missing part is requested.
+	backfillGracePeriod = 4 * time.Second
+	// backfillCheckPeriod is the interval of checking pending slots for missing parts.
+	backfillCheckPeriod = time.Second
+	// backfillTimeout bounds a single request of a missing part.
+	backfillTimeout = 5 * time.Second
+	// backfillMaxAttempts is the number of failed requests of a missing part before it is not requested anymore,
+	// e.g. the part of a skipped pandora block or an orphaned vanguard slot never exists.
+	backfillMaxAttempts = 3
+)
+
+// backfillMissing requests the missing pandora header or vanguard block of every pending slot
+// which waited longer than the grace period. Every slot is requested once at a time, the results
+// arrive on the backfill channels and are processed like the subscribed ones. A failed request is
+// retried on the next check, up to backfillMaxAttempts times per slot.
+func (s *Service) backfillMissing() {
+	now := time.Now()
+	for slot, arrival := range s.slotArrivals {
+		if s.backfillRequested[slot] || s.backfillAttempts[slot] >= backfillMaxAttempts ||
+			now.Sub(arrival) < backfillGracePeriod {
+			continue
+		}
+		header, _ := s.pandoraPendingHeaderCache.Get(s.ctx, slot)
+		shardInfo, _ := s.vanguardPendingShardingCache.Get(s.ctx, slot)
+		switch {
+		case shardInfo != nil && header == nil:
+			s.backfillRequested[slot] = true
+			go s.backfillPandoraHeader(slot, shardInfo)
+		case header != nil && shardInfo == nil:
+			s.backfillRequested[slot] = true
+			go s.backfillVanguardShard(slot)
+		}
+	}
+}
+
+// backfillPandoraHeader requests the pandora header referenced by the shard info, by hash and then by number.
+func (s *Service) backfillPandoraHeader(slot uint64, shardInfo *types.VanguardShardInfo) {
+	ctx, cancel := context.WithTimeout(s.ctx, backfillTimeout)
+	defer cancel()
+
+	logger := log.WithField("slot", slot).WithField("panBlockNum", shardInfo.ShardInfo.GetBlockNumber())
+	headerInfo, err := s.pandoraService.HeaderByHash(ctx, common.BytesToHash(shardInfo.ShardInfo.GetHash()))
+	if err != nil {
+		logger.WithError(err).Debug("Could not fetch pandora header by hash, fetching by number")
+		headerInfo, err = s.pandoraService.HeaderByNumber(ctx, shardInfo.ShardInfo.GetBlockNumber())
+	}
+	if err != nil {
+		backfills.WithLabelValues("pandora", "failed").Inc()
+		logger.WithError(err).Warn("Failed to backfill missing pandora header")
+		s.backfillFailed(slot)
+		return
+	}
+	if headerInfo.Slot != slot {
+		backfills.WithLabelValues("pandora", "failed").Inc()
+		logger.WithField("headerSlot", headerInfo.Slot).Warn("Backfilled pandora header belongs to another slot")
+		s.backfillFailed(slot)
+		return
+	}
+	backfills.WithLabelValues("pandora", "fetched").Inc()
+	logger.WithField("headerHash", headerInfo.Header.Hash()).Info("Backfilled missing pandora header")
+
+	select {
+	case s.backfilledHeaderCh <- headerInfo:
+	case <-s.ctx.Done():
+	}
+}
+
+// backfillVanguardShard requests the vanguard block of the slot.
+func (s *Service) backfillVanguardShard(slot uint64) {
+	ctx, cancel := context.WithTimeout(s.ctx, backfillTimeout)
+	defer cancel()
+
+	logger := log.WithField("slot", slot)
+	shardInfo, err := s.vanguardService.BlockBySlot(ctx, slot)
+	if err != nil {
+		backfills.WithLabelValues("vanguard", "failed").Inc()
+		logger.WithError(err).Warn("Failed to backfill missing vanguard block")
+		s.backfillFailed(slot)
+		return
+	}
+	backfills.WithLabelValues("vanguard", "fetched").Inc()
+	logger.WithField("panBlockNum", shardInfo.ShardInfo.GetBlockNumber()).Info("Backfilled missing vanguard block")
+
+	select {
+	case s.backfilledShardCh <- shardInfo:
+	case <-s.ctx.Done():
+	}
+}
+
+// backfillFailed hands the slot of a failed request back to the main loop, see onBackfillFailed.
+func (s *Service) backfillFailed(slot uint64) {
+	select {
+	case s.backfillFailedCh <- slot:
+	case <-s.ctx.Done():
+	}
+}
+
+// onBackfillFailed clears the mark of the slot of a failed request, so it is requested again on the next
+// check until it failed backfillMaxAttempts times.
+func (s *Service) onBackfillFailed(slot uint64) {
+	delete(s.backfillRequested, slot)
+	s.backfillAttempts[slot]++
+	if s.backfillAttempts[slot] >= backfillMaxAttempts {
+		log.WithField("slot", slot).WithField("attempts", s.backfillAttempts[slot]).
+			Warn("Giving up backfilling the missing part of the slot")
+	}
+}
@@ -0,0 +1,95 @@
+package consensus
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_Backfill(t *testing.T) {
+	defer func(grace, check time.Duration) {
+		backfillGracePeriod, backfillCheckPeriod = grace, check
+	}(backfillGracePeriod, backfillCheckPeriod)
+	backfillGracePeriod = 100 * time.Millisecond
+	backfillCheckPeriod = 50 * time.Millisecond
+
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 3)
+	ctx := context.Background()
+	svc, mockedFeed := setup(ctx, t)
+	defer svc.Stop()
+	mockedFeed.headers = map[common.Hash]*types.PandoraHeaderInfo{headerInfos[0].Header.Hash(): headerInfos[0]}
+	mockedFeed.blocks = map[uint64]*types.VanguardShardInfo{2: shardInfos[1]}
+	svc.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	// the pandora header of slot 1 never arrives
+	mockedFeed.shardInfoFeed.Send(shardInfos[0])
+	time.Sleep(500 * time.Millisecond)
+	slotInfo, err := svc.verifiedSlotInfoDB.VerifiedSlotInfo(1)
+	require.NoError(t, err)
+	require.NotNil(t, slotInfo)
+	assert.Equal(t, headerInfos[0].Header.Hash(), slotInfo.PandoraHeaderHash)
+
+	// the vanguard block of slot 2 never arrives
+	mockedFeed.headerInfoFeed.Send(headerInfos[1])
+	time.Sleep(500 * time.Millisecond)
+	slotInfo, err = svc.verifiedSlotInfoDB.VerifiedSlotInfo(2)
+	require.NoError(t, err)
+	require.NotNil(t, slotInfo)
+	assert.Equal(t, common.BytesToHash(shardInfos[1].BlockHash), slotInfo.VanguardBlockHash)
+}
+
+func TestService_BackfillRetry(t *testing.T) {
+	defer func(grace, check time.Duration) {
+		backfillGracePeriod, backfillCheckPeriod = grace, check
+	}(backfillGracePeriod, backfillCheckPeriod)
+	backfillGracePeriod = 100 * time.Millisecond
+	backfillCheckPeriod = 50 * time.Millisecond
+
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 2)
+	ctx := context.Background()
+	svc, mockedFeed := setup(ctx, t)
+	defer svc.Stop()
+	mockedFeed.blocks = map[uint64]*types.VanguardShardInfo{1: shardInfos[0]}
+	mockedFeed.blockFailures = 2
+	svc.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	// the first requests of the missing vanguard block fail, the next tick requests it again
+	mockedFeed.headerInfoFeed.Send(headerInfos[0])
+	time.Sleep(500 * time.Millisecond)
+	slotInfo, err := svc.verifiedSlotInfoDB.VerifiedSlotInfo(1)
+	require.NoError(t, err)
+	require.NotNil(t, slotInfo)
+	assert.Equal(t, common.BytesToHash(shardInfos[0].BlockHash), slotInfo.VanguardBlockHash)
+}
+
+func TestService_BackfillGivesUp(t *testing.T) {
+	defer func(grace, check time.Duration) {
+		backfillGracePeriod, backfillCheckPeriod = grace, check
+	}(backfillGracePeriod, backfillCheckPeriod)
+	backfillGracePeriod = 100 * time.Millisecond
+	backfillCheckPeriod = 20 * time.Millisecond
+
+	headerInfos, _ := getHeaderInfosAndShardInfos(1, 2)
+	ctx := context.Background()
+	svc, mockedFeed := setup(ctx, t)
+	defer svc.Stop()
+	mockedFeed.blockFailures = 100
+	svc.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	// the vanguard block of slot 1 never exists, it is requested a limited number of times
+	mockedFeed.headerInfoFeed.Send(headerInfos[0])
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, int32(backfillMaxAttempts), atomic.LoadInt32(&mockedFeed.blockRequests))
+	slotInfo, err := svc.verifiedSlotInfoDB.VerifiedSlotInfo(1)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.SlotInfo)(nil), slotInfo)
+}
@@ -11,6 +11,7 @@ import (
 // processPandoraHeader
 func (s *Service) processPandoraHeader(headerInfo *types.PandoraHeaderInfo) error {
 	slot := headerInfo.Slot
+	s.markArrival(slot)
 	s.pandoraPendingHeaderCache.Put(s.ctx, slot, headerInfo.Header)
 	vanShardInfo, _ := s.vanguardPendingShardingCache.Get(s.ctx, slot)
 	if vanShardInfo != nil {
@@ -22,6 +23,7 @@ func (s *Service) processPandoraHeader(headerInfo *types.PandoraHeaderInfo) erro
 // processVanguardShardInfo
 func (s *Service) processVanguardShardInfo(vanShardInfo *types.VanguardShardInfo) error {
 	slot := vanShardInfo.Slot
+	s.markArrival(slot)
 	s.vanguardPendingShardingCache.Put(s.ctx, slot, vanShardInfo)
 	headerInfo, _ := s.pandoraPendingHeaderCache.Get(s.ctx, slot)
 	if headerInfo != nil {
@@ -51,6 +53,7 @@ func (s *Service) verifyShardingInfo(slot uint64, vanShardInfo *types.VanguardSh
 		}
 		slotInfoWithStatus.Status = types.Invalid
 		log.WithField("slot", slot).Info("Invalid sharding info")
+		s.reportVerification(slot, types.Invalid)
 		// sending verified slot info to rpc service
 		s.verifiedSlotInfoFeed.Send(slotInfoWithStatus)
 		return nil
@@ -73,32 +76,96 @@ func (s *Service) verifyShardingInfo(slot uint64, vanShardInfo *types.VanguardSh
 		log.WithError(err).Error("Failed to store latest verified slot")
 	}
 
-	// Storing latest finalized slot and epoch
-	if s.verifiedSlotInfoDB.LatestLatestFinalizedEpoch() < vanShardInfo.FinalizedEpoch {
-		if err := s.verifiedSlotInfoDB.SaveLatestFinalizedSlot(vanShardInfo.FinalizedSlot); err != nil {
-			log.WithError(err).Warn("Failed to store new finalized info")
-		}
-
-		if err := s.verifiedSlotInfoDB.SaveLatestFinalizedEpoch(vanShardInfo.FinalizedEpoch); err != nil {
-			log.WithError(err).Warn("Failed to store new finalized epoch")
-		}
-		log.WithField("newFinalizedSlot", vanShardInfo.FinalizedSlot).
-			WithField("newFinalizedEpoch", vanShardInfo.FinalizedEpoch).Debug("Saved latest finalized info")
-	}
-
 	slotInfoWithStatus.Status = types.Verified
 	//removing previous cached slots which dont verified yet. By convention, they are skipped
 	s.pandoraPendingHeaderCache.Remove(s.ctx, slot)
 	s.vanguardPendingShardingCache.Remove(s.ctx, slot)
 	log.WithField("slot", slot).Info("Successfully verified sharding info")
+	s.reportVerification(slot, types.Verified)
 	// sending verified slot info to rpc service
 	s.verifiedSlotInfoFeed.Send(slotInfoWithStatus)
+
+	// Storing latest finalized slot and epoch, the slot is verified before it is finalized
+	s.saveFinalizedInfo(vanShardInfo.FinalizedSlot, vanShardInfo.FinalizedEpoch)
+	s.applyChainHeadFinality()
 	return nil
 }
 
+// applyChainHeadFinality stores the finality of the latest vanguard chain head once its finalized slot is verified,
+// so finality advances even when no new vanguard block carries it. The finality is skipped when the verified
+// vanguard block of the finalized slot is not the finalized one.
+func (s *Service) applyChainHeadFinality() {
+	if s.chainHead == nil || s.chainHead.FinalizedSlot > s.verifiedSlotInfoDB.LatestSavedVerifiedSlot() {
+		return
+	}
+	if s.chainHead.FinalizedEpoch <= s.verifiedSlotInfoDB.LatestLatestFinalizedEpoch() {
+		return
+	}
+	slotInfo, err := s.verifiedSlotInfoDB.VerifiedSlotInfo(s.chainHead.FinalizedSlot)
+	if err != nil {
+		log.WithError(err).Warn("Failed to read the verified slot info of the finalized slot")
+		return
+	}
+	if slotInfo == nil || slotInfo.VanguardBlockHash != s.chainHead.FinalizedBlockRoot {
+		log.WithField("finalizedSlot", s.chainHead.FinalizedSlot).
+			WithField("finalizedBlockRoot", s.chainHead.FinalizedBlockRoot).
+			Warn("Finalized vanguard block is not the verified one, skipping chain head finality")
+		return
+	}
+	s.saveFinalizedInfo(s.chainHead.FinalizedSlot, s.chainHead.FinalizedEpoch)
+}
+
+// saveFinalizedInfo stores the given finalized slot and epoch when the epoch is newer than the stored one
+// and notifies the subscribers about the verified slots which became finalized.
+func (s *Service) saveFinalizedInfo(finalizedSlot, finalizedEpoch uint64) {
+	if s.verifiedSlotInfoDB.LatestLatestFinalizedEpoch() < finalizedEpoch {
+		if err := s.verifiedSlotInfoDB.SaveLatestFinalizedSlot(finalizedSlot); err != nil {
+			log.WithError(err).Warn("Failed to store new finalized info")
+		}
+
+		if err := s.verifiedSlotInfoDB.SaveLatestFinalizedEpoch(finalizedEpoch); err != nil {
+			log.WithError(err).Warn("Failed to store new finalized epoch")
+		}
+		log.WithField("newFinalizedSlot", finalizedSlot).
+			WithField("newFinalizedEpoch", finalizedEpoch).Debug("Saved latest finalized info")
+	}
+	s.sendFinalizedSlotInfos()
+}
+
+// sendFinalizedSlotInfos sends a finalized status for every verified slot after the last notified one up
+// to the stored finalized slot. Finalized slots which are not verified yet are notified once they are.
+func (s *Service) sendFinalizedSlotInfos() {
+	finalizedSlot := s.verifiedSlotInfoDB.LatestLatestFinalizedSlot()
+	if latestVerifiedSlot := s.verifiedSlotInfoDB.LatestSavedVerifiedSlot(); finalizedSlot > latestVerifiedSlot {
+		finalizedSlot = latestVerifiedSlot
+	}
+	if finalizedSlot <= s.notifiedFinalizedSlot {
+		return
+	}
+	slotInfos, err := s.verifiedSlotInfoDB.VerifiedSlotInfos(s.notifiedFinalizedSlot + 1)
+	if err != nil {
+		log.WithError(err).Warn("Failed to read finalized slot infos")
+		return
+	}
+	for slot := s.notifiedFinalizedSlot + 1; slot <= finalizedSlot; slot++ {
+		slotInfo := slotInfos[slot]
+		if slotInfo == nil {
+			continue
+		}
+		s.verifiedSlotInfoFeed.Send(&types.SlotInfoWithStatus{
+			VanguardBlockHash: slotInfo.VanguardBlockHash,
+			PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+			Status:            types.Finalized,
+		})
+	}
+	s.notifiedFinalizedSlot = finalizedSlot
+}
+
 func (s *Service) reorgDB(revertSlot uint64) error {
+	latestVerifiedSlot := s.verifiedSlotInfoDB.LatestSavedVerifiedSlot()
+
 	// Removing slot infos from verified slot info db
-	if err := s.verifiedSlotInfoDB.RemoveRangeVerifiedInfo(revertSlot+1, s.verifiedSlotInfoDB.LatestSavedVerifiedSlot()); err != nil {
+	if err := s.verifiedSlotInfoDB.RemoveRangeVerifiedInfo(revertSlot+1, latestVerifiedSlot); err != nil {
 		log.WithError(err).Error("found error while reverting orchestrator database in reorg phase")
 		return err
 	}
@@ -0,0 +1,65 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	verificationOutcomes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "orchestrator_verification_outcomes_total",
+		Help: "Number of verified slot outcomes, partitioned by status",
+	}, []string{"status"})
+	verificationLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "orchestrator_verification_latency_seconds",
+		Help:    "Time between the first arrival of a slot from either chain and its match",
+		Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2, 4, 6, 12, 30, 60},
+	})
+	reorgCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "orchestrator_reorgs_total",
+		Help: "Number of reorgs handled by the consensus service",
+	})
+	reorgDepth = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "orchestrator_reorg_depth_slots",
+		Help:    "Number of verified slots reverted by a reorg",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+	})
+	backfills = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "orchestrator_backfills_total",
+		Help: "Number of requests of missing pandora headers and vanguard blocks, partitioned by chain and result",
+	}, []string{"chain", "result"})
+)
+
+// markArrival remembers the time when a slot first arrived from any of the chains.
+func (s *Service) markArrival(slot uint64) {
+	if _, exists := s.slotArrivals[slot]; !exists {
+		s.slotArrivals[slot] = time.Now()
+	}
+}
+
+// reportVerification records the outcome of a slot verification and, for the first
+// outcome of the slot, the time it took since the slot first arrived.
+func (s *Service) reportVerification(slot uint64, status types.Status) {
+	verificationOutcomes.WithLabelValues(string(status)).Inc()
+	if arrival, exists := s.slotArrivals[slot]; exists {
+		verificationLatency.Observe(time.Since(arrival).Seconds())
+	}
+	// previous slots are skipped by convention, so forget about them as well
+	for arrivalSlot := range s.slotArrivals {
+		if arrivalSlot <= slot {
+			delete(s.slotArrivals, arrivalSlot)
+			delete(s.backfillRequested, arrivalSlot)
+			delete(s.backfillAttempts, arrivalSlot)
+		}
+	}
+}
+
+// resetArrivals forgets all pending slot arrivals, i.e. after a reorg purged the caches.
+func (s *Service) resetArrivals() {
+	s.slotArrivals = make(map[uint64]time.Time)
+	s.backfillRequested = make(map[uint64]bool)
+	s.backfillAttempts = make(map[uint64]int)
+}
@@ -3,6 +3,7 @@ package consensus
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -13,6 +14,7 @@ import (
 	iface2 "github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain/iface"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain/iface"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
 )
 
 type Config struct {
@@ -25,6 +27,21 @@ type Config struct {
 	PandoraHeaderFeed iface2.PandoraService
 }
 
+var (
+	errRevertBelowFinalized = errors.New("cannot revert below the latest finalized slot")
+	errRevertAboveVerified  = errors.New("cannot revert above the latest verified slot")
+)
+
+// revertRequest asks the consensus loop to revert verified slot infos to the given slot
+type revertRequest struct {
+	slot  uint64
+	errCh chan error
+}
+
+// maxSyncedSlotLag is the highest lag between vanguard head slot and latest verified slot
+// for which the orchestrator is still considered in sync.
+var maxSyncedSlotLag = uint64(8)
+
 // Service This part could be moved to other place during refactor, might be registered as a service
 type Service struct {
 	isRunning      bool
@@ -43,22 +60,52 @@ type Service struct {
 	pandoraService       iface2.PandoraService
 	verifiedSlotInfoFeed event.Feed
 	reorgInProgress      bool
+
+	revertRequestCh chan *revertRequest
+
+	// slotArrivals keeps the first arrival time of not yet verified slots
+	slotArrivals map[uint64]time.Time
+	// backfillRequested keeps the pending slots whose missing part has been requested
+	backfillRequested map[uint64]bool
+	// backfillAttempts counts the failed requests of the missing part of the pending slots
+	backfillAttempts   map[uint64]int
+	backfilledHeaderCh chan *types.PandoraHeaderInfo
+	backfilledShardCh  chan *types.VanguardShardInfo
+	backfillFailedCh   chan uint64
+
+	// chainHead is the latest vanguard chain head, its finality is applied once the finalized slot is verified
+	chainHead *types.VanguardChainHead
+	// notifiedFinalizedSlot is the last verified slot whose finalized status has been sent
+	notifiedFinalizedSlot uint64
 }
 
-//
 func New(ctx context.Context, cfg *Config) (service *Service) {
 	ctx, cancel := context.WithCancel(ctx)
 	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
 
+	// the finalized status of the verified slots up to the stored finalized slot has been sent before the restart
+	notifiedFinalizedSlot := cfg.VerifiedSlotInfoDB.LatestLatestFinalizedSlot()
+	if latestVerifiedSlot := cfg.VerifiedSlotInfoDB.LatestSavedVerifiedSlot(); notifiedFinalizedSlot > latestVerifiedSlot {
+		notifiedFinalizedSlot = latestVerifiedSlot
+	}
+
 	return &Service{
 		ctx:                          ctx,
 		cancel:                       cancel,
+		notifiedFinalizedSlot:        notifiedFinalizedSlot,
 		verifiedSlotInfoDB:           cfg.VerifiedSlotInfoDB,
 		invalidSlotInfoDB:            cfg.InvalidSlotInfoDB,
 		vanguardPendingShardingCache: cfg.VanguardPendingShardingCache,
 		pandoraPendingHeaderCache:    cfg.PandoraPendingHeaderCache,
 		vanguardService:              cfg.VanguardShardFeed,
 		pandoraService:               cfg.PandoraHeaderFeed,
+		revertRequestCh:              make(chan *revertRequest),
+		slotArrivals:                 make(map[uint64]time.Time),
+		backfillRequested:            make(map[uint64]bool),
+		backfillAttempts:             make(map[uint64]int),
+		backfilledHeaderCh:           make(chan *types.PandoraHeaderInfo, 1),
+		backfilledShardCh:            make(chan *types.VanguardShardInfo, 1),
+		backfillFailedCh:             make(chan uint64, 1),
 	}
 }
 
@@ -73,93 +120,74 @@ func (s *Service) Start() {
 		vanShardInfoCh := make(chan *types.VanguardShardInfo, 1)
 		reorgSignalCh := make(chan *types.Reorg, 1)
 		panHeaderInfoCh := make(chan *types.PandoraHeaderInfo, 1)
+		chainHeadCh := make(chan *types.VanguardChainHead, 1)
 
 		vanShardInfoSub := s.vanguardService.SubscribeShardInfoEvent(vanShardInfoCh)
 		vanShutdownSub := s.vanguardService.SubscribeShutdownSignalEvent(reorgSignalCh)
 		panHeaderInfoSub := s.pandoraService.SubscribeHeaderInfoEvent(panHeaderInfoCh)
+		chainHeadSub := s.vanguardService.SubscribeChainHeadEvent(chainHeadCh)
+
+		// the chain head restored by the vanguard service is applied without waiting for the next one
+		s.chainHead = s.vanguardService.ChainHead()
+		s.applyChainHeadFinality()
+
+		backfillTicker := time.NewTicker(backfillCheckPeriod)
+		defer backfillTicker.Stop()
 
 		for {
 			select {
 			case newPanHeaderInfo := <-panHeaderInfoCh:
-
-				if s.reorgInProgress {
-					log.WithField("slot", newPanHeaderInfo.Slot).Info("Reorg is progressing, so skipping new pandora header")
-					continue
-				}
-
-				if slotInfo, _ := s.verifiedSlotInfoDB.VerifiedSlotInfo(newPanHeaderInfo.Slot); slotInfo != nil {
-					if slotInfo.PandoraHeaderHash == newPanHeaderInfo.Header.Hash() {
-						log.WithField("slot", newPanHeaderInfo.Slot).
-							WithField("headerHash", newPanHeaderInfo.Header.Hash()).
-							Info("Pandora header is already in verified slot info db")
-
-						s.verifiedSlotInfoFeed.Send(&types.SlotInfoWithStatus{
-							VanguardBlockHash: slotInfo.VanguardBlockHash,
-							PandoraHeaderHash: slotInfo.PandoraHeaderHash,
-							Status:            types.Verified,
-						})
-
-						continue
-					}
-				}
-
-				if err := s.processPandoraHeader(newPanHeaderInfo); err != nil {
+				if err := s.onPandoraHeader(newPanHeaderInfo); err != nil {
 					log.WithField("error", err).Error("error found while processing pandora header")
 					return
 				}
-			case newVanShardInfo := <-vanShardInfoCh:
-
-				if s.reorgInProgress {
-					log.WithField("slot", newVanShardInfo.Slot).Info("Reorg is progressing, so skipping new vanguard shard")
-					continue
-				}
-
-				if slotInfo, _ := s.verifiedSlotInfoDB.VerifiedSlotInfo(newVanShardInfo.Slot); slotInfo != nil {
-					blockHashHex := common.BytesToHash(newVanShardInfo.BlockHash[:])
-					if slotInfo.VanguardBlockHash == blockHashHex {
-						log.WithField("slot", newVanShardInfo.Slot).
-							WithField("shardInfoHash", hexutil.Encode(newVanShardInfo.ShardInfo.Hash)).
-							Info("Vanguard shard info is already in verified slot info db")
-
-						continue
-					}
+			case newPanHeaderInfo := <-s.backfilledHeaderCh:
+				if err := s.onPandoraHeader(newPanHeaderInfo); err != nil {
+					log.WithField("error", err).Error("error found while processing backfilled pandora header")
+					return
 				}
-
-				if err := s.processVanguardShardInfo(newVanShardInfo); err != nil {
+			case newVanShardInfo := <-vanShardInfoCh:
+				if err := s.onVanguardShard(newVanShardInfo); err != nil {
 					log.WithField("error", err).Error("error found while processing vanguard sharding info")
 					return
 				}
+			case newVanShardInfo := <-s.backfilledShardCh:
+				if err := s.onVanguardShard(newVanShardInfo); err != nil {
+					log.WithField("error", err).Error("error found while processing backfilled vanguard sharding info")
+					return
+				}
+			case chainHead := <-chainHeadCh:
+				s.chainHead = chainHead
+				s.applyChainHeadFinality()
+			case slot := <-s.backfillFailedCh:
+				s.onBackfillFailed(slot)
+			case <-backfillTicker.C:
+				if !s.reorgInProgress {
+					s.backfillMissing()
+				}
 			case reorgInfo := <-reorgSignalCh:
 				if reorgInfo == nil {
 					log.Error("received shutdown signal but value not set. So we are doing nothing")
 					continue
 				}
-				s.reorgInProgress = true
 				// reorg happened. So remove info from database
 				finalizedSlot := s.verifiedSlotInfoDB.LatestLatestFinalizedSlot()
 				finalizedEpoch := s.verifiedSlotInfoDB.LatestLatestFinalizedEpoch()
 				log.WithField("curSlot", reorgInfo.NewSlot).WithField("revertSlot", finalizedSlot).
 					WithField("finalizedEpoch", finalizedEpoch).Warn("Triggered reorg event")
 
-				if err := s.reorgDB(finalizedSlot); err != nil {
+				if err := s.revert(finalizedSlot); err != nil {
 					log.WithError(err).Warn("Failed to revert verified info db, exiting consensus go routine")
 					return
 				}
-				// Removing slot infos from vanguard cache and pandora cache
-				s.vanguardPendingShardingCache.Purge()
-				s.pandoraPendingHeaderCache.Purge()
-				log.Debug("Starting subscription for vanguard and pandora")
-
-				// disconnect subscription
-				log.Debug("Stopping subscription for vanguard and pandora")
-				s.vanguardService.StopSubscription()
-				s.pandoraService.StopPandoraSubscription()
-
-				s.reorgInProgress = false
+			case req := <-s.revertRequestCh:
+				log.WithField("revertSlot", req.slot).Warn("Triggered manual revert")
+				req.errCh <- s.revert(req.slot)
 			case <-s.ctx.Done():
 				vanShardInfoSub.Unsubscribe()
 				vanShutdownSub.Unsubscribe()
 				panHeaderInfoSub.Unsubscribe()
+				chainHeadSub.Unsubscribe()
 				log.Info("Received cancelled context,closing existing consensus service")
 				return
 			}
@@ -167,6 +195,107 @@ func (s *Service) Start() {
 	}()
 }
 
+// onPandoraHeader processes a new pandora header unless a reorg is in progress or it is already verified.
+func (s *Service) onPandoraHeader(newPanHeaderInfo *types.PandoraHeaderInfo) error {
+	if s.reorgInProgress {
+		log.WithField("slot", newPanHeaderInfo.Slot).Info("Reorg is progressing, so skipping new pandora header")
+		return nil
+	}
+
+	if slotInfo, _ := s.verifiedSlotInfoDB.VerifiedSlotInfo(newPanHeaderInfo.Slot); slotInfo != nil {
+		if slotInfo.PandoraHeaderHash == newPanHeaderInfo.Header.Hash() {
+			log.WithField("slot", newPanHeaderInfo.Slot).
+				WithField("headerHash", newPanHeaderInfo.Header.Hash()).
+				Info("Pandora header is already in verified slot info db")
+
+			status := types.Verified
+			if newPanHeaderInfo.Slot <= s.verifiedSlotInfoDB.LatestLatestFinalizedSlot() {
+				status = types.Finalized
+			}
+			s.verifiedSlotInfoFeed.Send(&types.SlotInfoWithStatus{
+				VanguardBlockHash: slotInfo.VanguardBlockHash,
+				PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+				Status:            status,
+			})
+			return nil
+		}
+	}
+
+	return s.processPandoraHeader(newPanHeaderInfo)
+}
+
+// onVanguardShard processes a new vanguard shard info unless a reorg is in progress or it is already verified.
+func (s *Service) onVanguardShard(newVanShardInfo *types.VanguardShardInfo) error {
+	if s.reorgInProgress {
+		log.WithField("slot", newVanShardInfo.Slot).Info("Reorg is progressing, so skipping new vanguard shard")
+		return nil
+	}
+
+	if slotInfo, _ := s.verifiedSlotInfoDB.VerifiedSlotInfo(newVanShardInfo.Slot); slotInfo != nil {
+		blockHashHex := common.BytesToHash(newVanShardInfo.BlockHash[:])
+		if slotInfo.VanguardBlockHash == blockHashHex {
+			log.WithField("slot", newVanShardInfo.Slot).
+				WithField("shardInfoHash", hexutil.Encode(newVanShardInfo.ShardInfo.Hash)).
+				Info("Vanguard shard info is already in verified slot info db")
+			return nil
+		}
+	}
+
+	return s.processVanguardShardInfo(newVanShardInfo)
+}
+
+// revert removes verified slot infos after the given slot, purges the pending caches and makes
+// vanguard and pandora subscriptions start again from the reverted state.
+func (s *Service) revert(revertSlot uint64) error {
+	s.setReorgInProgress(true)
+	defer s.setReorgInProgress(false)
+
+	if latestVerifiedSlot := s.verifiedSlotInfoDB.LatestSavedVerifiedSlot(); latestVerifiedSlot > revertSlot {
+		reorgDepth.Observe(float64(latestVerifiedSlot - revertSlot))
+	}
+	reorgCount.Inc()
+
+	if err := s.reorgDB(revertSlot); err != nil {
+		return err
+	}
+	if s.notifiedFinalizedSlot > revertSlot {
+		s.notifiedFinalizedSlot = revertSlot
+	}
+	// Removing slot infos from vanguard cache and pandora cache
+	s.vanguardPendingShardingCache.Purge()
+	s.pandoraPendingHeaderCache.Purge()
+	s.resetArrivals()
+
+	// disconnect subscription
+	log.Debug("Stopping subscription for vanguard and pandora")
+	s.vanguardService.StopSubscription()
+	s.pandoraService.StopPandoraSubscription()
+	return nil
+}
+
+// RevertToSlot reverts verified slot infos to the given slot, which must not be lower than the latest finalized slot.
+func (s *Service) RevertToSlot(slot uint64) error {
+	if finalizedSlot := s.verifiedSlotInfoDB.LatestLatestFinalizedSlot(); slot < finalizedSlot {
+		return errors.Wrapf(errRevertBelowFinalized, "requested slot %d, finalized slot %d", slot, finalizedSlot)
+	}
+	if latestVerifiedSlot := s.verifiedSlotInfoDB.LatestSavedVerifiedSlot(); slot > latestVerifiedSlot {
+		return errors.Wrapf(errRevertAboveVerified, "requested slot %d, latest verified slot %d", slot, latestVerifiedSlot)
+	}
+
+	req := &revertRequest{slot: slot, errCh: make(chan error, 1)}
+	select {
+	case s.revertRequestCh <- req:
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+	select {
+	case err := <-req.errCh:
+		return err
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
 func (s *Service) Stop() error {
 	if s.cancel != nil {
 		defer s.cancel()
@@ -189,3 +318,35 @@ func (s *Service) Status() error {
 func (s *Service) SubscribeVerifiedSlotInfoEvent(ch chan<- *types.SlotInfoWithStatus) event.Subscription {
 	return s.scope.Track(s.verifiedSlotInfoFeed.Subscribe(ch))
 }
+
+// SyncStatus reports the connectivity of both chains and how far the latest verified slot lags behind
+// the vanguard head slot.
+func (s *Service) SyncStatus() *types.SyncStatus {
+	latestVerifiedSlot := s.verifiedSlotInfoDB.LatestSavedVerifiedSlot()
+	vanguardHeadSlot := s.vanguardService.HeadSlot()
+
+	var slotLag uint64
+	if vanguardHeadSlot > latestVerifiedSlot {
+		slotLag = vanguardHeadSlot - latestVerifiedSlot
+	}
+
+	s.processingLock.Lock()
+	reorgInProgress := s.reorgInProgress
+	s.processingLock.Unlock()
+
+	return &types.SyncStatus{
+		VanguardConnected:  s.vanguardService.IsConnected(),
+		PandoraConnected:   s.pandoraService.IsConnected(),
+		LatestVerifiedSlot: latestVerifiedSlot,
+		VanguardHeadSlot:   vanguardHeadSlot,
+		SlotLag:            slotLag,
+		ReorgInProgress:    reorgInProgress,
+		Syncing:            reorgInProgress || slotLag > maxSyncedSlotLag,
+	}
+}
+
+func (s *Service) setReorgInProgress(inProgress bool) {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	s.reorgInProgress = inProgress
+}
@@ -2,9 +2,11 @@ package consensus
 
 import (
 	"context"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	"testing"
 	"time"
@@ -73,3 +75,161 @@ func TestService(t *testing.T) {
 		})
 	}
 }
+
+func TestService_SyncStatus(t *testing.T) {
+	ctx := context.Background()
+	svc, mockedFeed := setup(ctx, t)
+	defer svc.Stop()
+
+	mockedFeed.connected = true
+	mockedFeed.headSlot = 100
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestVerifiedSlot(ctx, 20))
+
+	syncStatus := svc.SyncStatus()
+	assert.Equal(t, uint64(80), syncStatus.SlotLag)
+	assert.Equal(t, true, syncStatus.Syncing)
+	assert.Equal(t, false, syncStatus.Ready())
+
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestVerifiedSlot(ctx, 99))
+	syncStatus = svc.SyncStatus()
+	assert.Equal(t, uint64(1), syncStatus.SlotLag)
+	assert.Equal(t, false, syncStatus.Syncing)
+	assert.Equal(t, true, syncStatus.Ready())
+
+	svc.setReorgInProgress(true)
+	syncStatus = svc.SyncStatus()
+	assert.Equal(t, true, syncStatus.ReorgInProgress)
+	assert.Equal(t, false, syncStatus.Ready())
+	svc.setReorgInProgress(false)
+
+	mockedFeed.connected = false
+	assert.Equal(t, false, svc.SyncStatus().Ready())
+}
+
+func TestService_RevertToSlot(t *testing.T) {
+	ctx := context.Background()
+	svc, mockedFeed := setup(ctx, t)
+	defer svc.Stop()
+	svc.Start()
+
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 11)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, svc.verifiedSlotInfoDB.SaveVerifiedSlotInfo(shardInfos[i].Slot, &types.SlotInfo{
+			PandoraHeaderHash: headerInfos[i].Header.Hash(),
+		}))
+	}
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestVerifiedSlot(ctx, 10))
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestFinalizedSlot(4))
+
+	assert.ErrorContains(t, errRevertBelowFinalized.Error(), svc.RevertToSlot(3))
+	assert.ErrorContains(t, errRevertAboveVerified.Error(), svc.RevertToSlot(11))
+
+	reorgs := promtestutil.ToFloat64(reorgCount)
+	require.NoError(t, svc.RevertToSlot(6))
+	assert.Equal(t, reorgs+1, promtestutil.ToFloat64(reorgCount))
+	assert.Equal(t, uint64(6), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+	assert.Equal(t, headerInfos[5].Header.Hash(), svc.verifiedSlotInfoDB.LatestVerifiedHeaderHash())
+	slotInfo, _ := svc.verifiedSlotInfoDB.VerifiedSlotInfo(7)
+	assert.Equal(t, true, slotInfo == nil)
+	assert.Equal(t, 2, mockedFeed.stoppedSubscriptions)
+}
+
+func TestService_ChainHeadFinality(t *testing.T) {
+	ctx := context.Background()
+	svc, mockedFeed := setup(ctx, t)
+	defer svc.Stop()
+	svc.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 11)
+	for i, headerInfo := range headerInfos {
+		require.NoError(t, svc.verifiedSlotInfoDB.SaveVerifiedSlotInfo(headerInfo.Slot, &types.SlotInfo{
+			PandoraHeaderHash: headerInfo.Header.Hash(),
+			VanguardBlockHash: common.BytesToHash(shardInfos[i].BlockHash),
+		}))
+	}
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestVerifiedSlot(ctx, 10))
+	slotInfoCh := make(chan *types.SlotInfoWithStatus, 20)
+	sub := svc.SubscribeVerifiedSlotInfoEvent(slotInfoCh)
+	defer sub.Unsubscribe()
+
+	// finality is skipped when the finalized block is not the verified one
+	mockedFeed.chainHeadFeed.Send(&types.VanguardChainHead{HeadSlot: 40, FinalizedSlot: 8, FinalizedEpoch: 1,
+		FinalizedBlockRoot: common.HexToHash("0x01")})
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, uint64(0), svc.verifiedSlotInfoDB.LatestLatestFinalizedEpoch())
+	assert.Equal(t, 0, len(slotInfoCh))
+
+	mockedFeed.chainHeadFeed.Send(&types.VanguardChainHead{HeadSlot: 40, FinalizedSlot: 8, FinalizedEpoch: 1,
+		FinalizedBlockRoot: common.BytesToHash(shardInfos[7].BlockHash)})
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, uint64(8), svc.verifiedSlotInfoDB.LatestLatestFinalizedSlot())
+	assert.Equal(t, uint64(1), svc.verifiedSlotInfoDB.LatestLatestFinalizedEpoch())
+
+	// every verified slot up to the finalized one transitions to finalized
+	require.Equal(t, 8, len(slotInfoCh))
+	for i := 0; i < 8; i++ {
+		slotInfo := <-slotInfoCh
+		assert.Equal(t, types.Finalized, slotInfo.Status)
+		assert.Equal(t, headerInfos[i].Header.Hash(), slotInfo.PandoraHeaderHash)
+	}
+
+	// finality is not applied before the finalized slot is verified
+	mockedFeed.chainHeadFeed.Send(&types.VanguardChainHead{HeadSlot: 48, FinalizedSlot: 16, FinalizedEpoch: 2})
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, uint64(8), svc.verifiedSlotInfoDB.LatestLatestFinalizedSlot())
+}
+
+// TestService_RestoredChainHeadFinality checks that the chain head known at start is applied without waiting
+// for the next one.
+func TestService_RestoredChainHeadFinality(t *testing.T) {
+	ctx := context.Background()
+	svc, mockedFeed := setup(ctx, t)
+	defer svc.Stop()
+
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 9)
+	for i, headerInfo := range headerInfos {
+		require.NoError(t, svc.verifiedSlotInfoDB.SaveVerifiedSlotInfo(headerInfo.Slot, &types.SlotInfo{
+			PandoraHeaderHash: headerInfo.Header.Hash(),
+			VanguardBlockHash: common.BytesToHash(shardInfos[i].BlockHash),
+		}))
+	}
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestVerifiedSlot(ctx, 8))
+	mockedFeed.chainHead = &types.VanguardChainHead{HeadSlot: 40, FinalizedSlot: 8, FinalizedEpoch: 1,
+		FinalizedBlockRoot: common.BytesToHash(shardInfos[7].BlockHash)}
+
+	svc.Start()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, uint64(8), svc.verifiedSlotInfoDB.LatestLatestFinalizedSlot())
+	assert.Equal(t, uint64(1), svc.verifiedSlotInfoDB.LatestLatestFinalizedEpoch())
+}
+
+// TestService_FinalityAheadOfVerification checks that slots finalized before they are verified are first sent as
+// verified and then as finalized once they are verified.
+func TestService_FinalityAheadOfVerification(t *testing.T) {
+	ctx := context.Background()
+	svc, mockedFeed := setup(ctx, t)
+	defer svc.Stop()
+	svc.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	slotInfoCh := make(chan *types.SlotInfoWithStatus, 20)
+	sub := svc.SubscribeVerifiedSlotInfoEvent(slotInfoCh)
+	defer sub.Unsubscribe()
+
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 4)
+	for i, shardInfo := range shardInfos {
+		shardInfo.FinalizedSlot = 8
+		shardInfo.FinalizedEpoch = 1
+		mockedFeed.shardInfoFeed.Send(shardInfo)
+		mockedFeed.headerInfoFeed.Send(headerInfos[i])
+		time.Sleep(100 * time.Millisecond)
+
+		require.Equal(t, 2, len(slotInfoCh))
+		verified, finalized := <-slotInfoCh, <-slotInfoCh
+		assert.Equal(t, types.Verified, verified.Status)
+		assert.Equal(t, types.Finalized, finalized.Status)
+		assert.Equal(t, headerInfos[i].Header.Hash(), finalized.PandoraHeaderHash)
+	}
+	assert.Equal(t, uint64(8), svc.verifiedSlotInfoDB.LatestLatestFinalizedSlot())
+}
@@ -2,8 +2,11 @@ package consensus
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
@@ -14,8 +17,29 @@ import (
 type mockFeedService struct {
 	headerInfoFeed           event.Feed
 	shardInfoFeed            event.Feed
+	chainHeadFeed            event.Feed
 	subscriptionShutdownFeed event.Feed
 	scope                    event.SubscriptionScope
+	connected                bool
+	headSlot                 uint64
+	stoppedSubscriptions     int
+	// headers and blocks served to backfill requests
+	headers map[common.Hash]*types.PandoraHeaderInfo
+	blocks  map[uint64]*types.VanguardShardInfo
+	// number of block requests failing before the blocks are served
+	blockFailures int32
+	blockRequests int32
+	chainHead     *types.VanguardChainHead
+}
+
+var errNotFound = errors.New("not found")
+
+func (mc *mockFeedService) IsConnected() bool {
+	return mc.connected
+}
+
+func (mc *mockFeedService) HeadSlot() uint64 {
+	return mc.headSlot
 }
 
 func (mc *mockFeedService) SubscribeShutdownSignalEvent(signals chan<- *types.Reorg) event.Subscription {
@@ -27,21 +51,56 @@ func (mc *mockFeedService) ReSubscribeBlocksEvent() error {
 }
 
 func (mc *mockFeedService) StopSubscription() {
-	panic("implement me")
+	mc.stoppedSubscriptions++
 }
 
 func (mc *mockFeedService) StopPandoraSubscription() {
-	panic("implement StopPandoraSubscription")
+	mc.stoppedSubscriptions++
 }
 
 func (mc *mockFeedService) ResumePandoraSubscription() error {
 	panic("implement ResumePandoraSubscription")
 }
 
+func (mc *mockFeedService) HeaderByHash(ctx context.Context, hash common.Hash) (*types.PandoraHeaderInfo, error) {
+	if headerInfo, exists := mc.headers[hash]; exists {
+		return headerInfo, nil
+	}
+	return nil, errNotFound
+}
+
+func (mc *mockFeedService) HeaderByNumber(ctx context.Context, number uint64) (*types.PandoraHeaderInfo, error) {
+	for _, headerInfo := range mc.headers {
+		if headerInfo.Header.Number.Uint64() == number {
+			return headerInfo, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+func (mc *mockFeedService) BlockBySlot(ctx context.Context, slot uint64) (*types.VanguardShardInfo, error) {
+	atomic.AddInt32(&mc.blockRequests, 1)
+	if atomic.AddInt32(&mc.blockFailures, -1) >= 0 {
+		return nil, errNotFound
+	}
+	if shardInfo, exists := mc.blocks[slot]; exists {
+		return shardInfo, nil
+	}
+	return nil, errNotFound
+}
+
 func (mc *mockFeedService) SubscribeHeaderInfoEvent(ch chan<- *types.PandoraHeaderInfo) event.Subscription {
 	return mc.scope.Track(mc.headerInfoFeed.Subscribe(ch))
 }
 
+func (mc *mockFeedService) ChainHead() *types.VanguardChainHead {
+	return mc.chainHead
+}
+
+func (mc *mockFeedService) SubscribeChainHeadEvent(ch chan<- *types.VanguardChainHead) event.Subscription {
+	return mc.scope.Track(mc.chainHeadFeed.Subscribe(ch))
+}
+
 func (mc *mockFeedService) SubscribeShardInfoEvent(ch chan<- *types.VanguardShardInfo) event.Subscription {
 	return mc.scope.Track(mc.shardInfoFeed.Subscribe(ch))
 }
@@ -14,4 +14,10 @@ type VerifiedSlotInfoDB = iface.VerifiedSlotDatabase
 
 type InvalidSlotInfoDB = iface.InvalidSlotDatabase
 
+type HeadStateReader = iface.HeadStateReader
+
+type NetworkDB = iface.NetworkDatabase
+
+type ChainHeadDB = iface.ChainHeadDatabase
+
 type Database = iface.Database
@@ -3,12 +3,21 @@ package db
 import (
 	"context"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/memorydb"
 )
 
 // Assure that Store implements Database interface
 var _ Database = &kv.Store{}
 
+// Assure that in-memory Store implements Database interface
+var _ Database = &memorydb.Store{}
+
 // NewDB initializes a new DB.
 func NewDB(ctx context.Context, dirPath string, config *kv.Config) (Database, error) {
 	return kv.NewKVStore(ctx, dirPath, config)
 }
+
+// NewEphemeralDB initializes a new in-memory DB. Nothing is persisted to disk.
+func NewEphemeralDB(ctx context.Context) Database {
+	return memorydb.NewStore(ctx)
+}
@@ -3,6 +3,7 @@ package iface
 import (
 	"context"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 	"io"
 )
@@ -20,11 +21,14 @@ type ConsensusInfoAccessDatabase interface {
 
 	SaveConsensusInfo(ctx context.Context, consensusInfo *types.MinimalEpochConsensusInfo) error
 	SaveLatestEpoch(ctx context.Context, epoch uint64) error
+	RemoveRangeConsensusInfo(startEpoch, endEpoch uint64) error
 }
 
 type ReadOnlyVerifiedSlotInfoDatabase interface {
 	VerifiedSlotInfo(slot uint64) (*types.SlotInfo, error)
 	VerifiedSlotInfos(fromSlot uint64) (map[uint64]*types.SlotInfo, error)
+	VerifiedSlotByPandoraHash(hash common.Hash) (uint64, bool, error)
+	VerifiedSlotByVanguardHash(hash common.Hash) (uint64, bool, error)
 	LatestSavedVerifiedSlot() uint64
 	LatestVerifiedHeaderHash() common.Hash
 	LatestLatestFinalizedSlot() uint64
@@ -53,6 +57,26 @@ type InvalidSlotDatabase interface {
 	SaveInvalidSlotInfo(slot uint64, slotInfo *types.SlotInfo) error
 }
 
+// HeadStateReader gives lock-free access to the latest info markers and notifies on every change.
+type HeadStateReader interface {
+	HeadState() *types.HeadState
+	SubscribeHeadStateEvent(ch chan<- *types.HeadState) event.Subscription
+}
+
+// NetworkDatabase pins the pandora and vanguard networks the database was built for.
+type NetworkDatabase interface {
+	PandoraNetwork() (*types.PandoraNetwork, error)
+	PinPandoraNetwork(network *types.PandoraNetwork) (*types.PandoraNetwork, error)
+	VanguardNetwork() (*types.VanguardNetwork, error)
+	PinVanguardNetwork(network *types.VanguardNetwork) (*types.VanguardNetwork, error)
+}
+
+// ChainHeadDatabase keeps the latest known head and checkpoints of the vanguard chain.
+type ChainHeadDatabase interface {
+	VanguardChainHead() (*types.VanguardChainHead, error)
+	SaveVanguardChainHead(chainHead *types.VanguardChainHead) error
+}
+
 // Database interface with full access.
 type Database interface {
 	io.Closer
@@ -63,6 +87,12 @@ type Database interface {
 
 	InvalidSlotDatabase
 
+	HeadStateReader
+
+	NetworkDatabase
+
+	ChainHeadDatabase
+
 	DatabasePath() string
 	ClearDB() error
 }
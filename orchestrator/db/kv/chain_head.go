@@ -0,0 +1,33 @@
+package kv
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// VanguardChainHead returns the latest saved vanguard chain head or nil when none is saved yet.
+func (s *Store) VanguardChainHead() (*types.VanguardChainHead, error) {
+	var chainHead *types.VanguardChainHead
+	err := s.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(latestInfoMarkerBucket).Get(vanguardChainHeadKey)
+		if value == nil {
+			return nil
+		}
+		return decode(value, &chainHead)
+	})
+	return chainHead, err
+}
+
+// SaveVanguardChainHead saves the latest vanguard chain head
+func (s *Store) SaveVanguardChainHead(chainHead *types.VanguardChainHead) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		enc, err := encode(chainHead)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(latestInfoMarkerBucket).Put(vanguardChainHeadKey, enc)
+	})
+}
@@ -0,0 +1,43 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_VanguardChainHead_PersistedOnReopen(t *testing.T) {
+	ctx := context.Background()
+	dbPath := t.TempDir()
+	db, err := NewKVStore(ctx, dbPath, &Config{})
+	require.NoError(t, err)
+
+	chainHead, err := db.VanguardChainHead()
+	require.NoError(t, err)
+	assert.Equal(t, (*types.VanguardChainHead)(nil), chainHead)
+
+	expected := &types.VanguardChainHead{
+		HeadSlot:           70,
+		HeadEpoch:          2,
+		HeadBlockRoot:      common.HexToHash("0x01"),
+		JustifiedSlot:      64,
+		JustifiedEpoch:     2,
+		JustifiedBlockRoot: common.HexToHash("0x02"),
+		FinalizedSlot:      32,
+		FinalizedEpoch:     1,
+		FinalizedBlockRoot: common.HexToHash("0x03"),
+	}
+	require.NoError(t, db.SaveVanguardChainHead(expected))
+	require.NoError(t, db.Close())
+
+	db, err = NewKVStore(ctx, dbPath, &Config{})
+	require.NoError(t, err)
+	defer db.Close()
+	chainHead, err = db.VanguardChainHead()
+	require.NoError(t, err)
+	assert.DeepEqual(t, expected, chainHead)
+}
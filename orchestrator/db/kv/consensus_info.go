@@ -117,38 +117,12 @@ func (s *Store) RemoveRangeConsensusInfo(startEpoch, endEpoch uint64) error {
 
 // LatestSavedEpoch
 func (s *Store) LatestSavedEpoch() uint64 {
-	var latestSavedEpoch uint64
-	// Db is not prepared yet. Retrieve latest saved epoch number from db
-	if !s.isRunning {
-		s.db.View(func(tx *bolt.Tx) error {
-			bkt := tx.Bucket(latestInfoMarkerBucket)
-			epochBytes := bkt.Get(lastStoredEpochKey[:])
-			// not found the latest epoch in db. so latest epoch will be zero
-			if epochBytes == nil {
-				latestSavedEpoch = uint64(0)
-				log.Trace("Latest epoch could not find in db. It may happen for brand new DB")
-				return nil
-			}
-			latestSavedEpoch = bytesutil.BytesToUint64BigEndian(epochBytes)
-			return nil
-		})
-	}
-	// db is already started so latest epoch must be initialized in store
-	return latestSavedEpoch
+	return s.headState().LatestEpoch
 }
 
 // SaveLatestEpoch
 func (s *Store) SaveLatestEpoch(ctx context.Context, epoch uint64) error {
-	s.Mutex.Lock()
-	defer s.Mutex.Unlock()
-
-	// storing latest epoch number into db
-	return s.db.Update(func(tx *bolt.Tx) error {
-		bkt := tx.Bucket(latestInfoMarkerBucket)
-		epochBytes := bytesutil.Uint64ToBytesBigEndian(epoch)
-		if err := bkt.Put(lastStoredEpochKey, epochBytes); err != nil {
-			return err
-		}
-		return nil
+	return s.updateHeadState(func(headState *eventTypes.HeadState) {
+		headState.LatestEpoch = epoch
 	})
 }
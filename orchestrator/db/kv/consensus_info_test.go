@@ -12,7 +12,7 @@ import (
 func TestStore_ConsensusInfo_RetrieveByEpoch_FromCache(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	db := setupDB(t, true)
+	db := setupDB(t)
 	totalConsensusInfos := make([]*eventTypes.MinimalEpochConsensusInfo, 50)
 	for i := 0; i < 50; i++ {
 		consensusInfo := testutil.NewMinimalConsensusInfo(uint64(i))
@@ -29,7 +29,7 @@ func TestStore_ConsensusInfo_RetrieveByEpoch_FromCache(t *testing.T) {
 func TestStore_ConsensusInfo_RetrieveByEpoch_FromDB(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	db := setupDB(t, true)
+	db := setupDB(t)
 	totalConsensusInfos := make([]*eventTypes.MinimalEpochConsensusInfo, 2001)
 	for i := 1; i <= 2000; i++ {
 		consensusInfo := testutil.NewMinimalConsensusInfo(uint64(i))
@@ -46,7 +46,7 @@ func TestStore_ConsensusInfo_RetrieveByEpoch_FromDB(t *testing.T) {
 func TestStore_SaveConsensusInfo_AlreadyExist(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	db := setupDB(t, true)
+	db := setupDB(t)
 
 	consensusInfo := testutil.NewMinimalConsensusInfo(0)
 	epochInfoV2 := consensusInfo.ConvertToEpochInfo()
@@ -58,7 +58,7 @@ func TestStore_SaveConsensusInfo_AlreadyExist(t *testing.T) {
 func TestStore_ConsensusInfos_RetrieveByEpoch(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	db := setupDB(t, true)
+	db := setupDB(t)
 	db.SaveLatestEpoch(ctx, 199)
 	totalConsensusInfos := make([]*eventTypes.MinimalEpochConsensusInfo, 200)
 
@@ -78,7 +78,7 @@ func TestStore_ConsensusInfos_RetrieveByEpoch(t *testing.T) {
 func TestStore_SaveLatestSavedEpoch_RetrieveLatestEpoch(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	db := setupDB(t, true)
+	db := setupDB(t)
 
 	// SaveLatestEpoch is called when db is going to close
 	require.NoError(t, db.SaveLatestEpoch(ctx, 1000))
@@ -91,22 +91,23 @@ func TestStore_SaveLatestSavedEpoch_RetrieveLatestEpoch(t *testing.T) {
 // TestDB_Close_Success
 func TestDB_Close_Success(t *testing.T) {
 	t.Parallel()
-	db := setupDB(t, true)
-
-	if db.isRunning {
-		require.NoError(t, db.Close())
-	}
+	db, err := NewKVStore(context.Background(), t.TempDir(), &Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
 }
 
 func TestStore_LatestEpoch_ClosingDB_OpeningDB(t *testing.T) {
 	t.Parallel()
-	db := setupDB(t, false)
-
-	if !db.isRunning {
-		t.SkipNow()
-
-		return
-	}
+	ctx := context.Background()
+	dbPath := t.TempDir()
+	db, err := NewKVStore(ctx, dbPath, &Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.SaveLatestEpoch(ctx, 1000))
+	require.NoError(t, db.Close())
 
+	// latest epoch must be loaded into the head state when db is going up
+	db, err = NewKVStore(ctx, dbPath, &Config{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), db.LatestSavedEpoch())
 	require.NoError(t, db.Close())
 }
@@ -0,0 +1,98 @@
+package kv
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// headState returns the in-memory head state. Reads are lock-free and the returned
+// value must never be mutated.
+func (s *Store) headState() *types.HeadState {
+	return s.head.Load().(*types.HeadState)
+}
+
+// HeadState returns a copy of the latest info markers.
+func (s *Store) HeadState() *types.HeadState {
+	return s.headState().Copy()
+}
+
+// SubscribeHeadStateEvent registers a subscription which fires whenever any latest info marker changes.
+func (s *Store) SubscribeHeadStateEvent(ch chan<- *types.HeadState) event.Subscription {
+	return s.scope.Track(s.headStateFeed.Subscribe(ch))
+}
+
+// loadHeadState reads the latest info markers from db into memory. It is called once when the store is opened.
+func (s *Store) loadHeadState() error {
+	headState := &types.HeadState{LatestVerifiedHeaderHash: EmptyHash}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(latestInfoMarkerBucket)
+		if enc := bkt.Get(lastStoredEpochKey); enc != nil {
+			headState.LatestEpoch = bytesutil.BytesToUint64BigEndian(enc)
+		} else {
+			log.Trace("Latest epoch could not find in db. It may happen for brand new DB")
+		}
+		if enc := bkt.Get(latestSavedVerifiedSlotKey); enc != nil {
+			headState.LatestVerifiedSlot = bytesutil.BytesToUint64BigEndian(enc)
+		} else {
+			log.Trace("Latest verified slot number could not find in db. It may happen for brand new DB")
+		}
+		if enc := bkt.Get(latestHeaderHashKey); enc != nil {
+			headState.LatestVerifiedHeaderHash = common.BytesToHash(enc)
+		} else {
+			log.Trace("Latest verified header hash could not find in db. Brand new DB.")
+		}
+		if enc := bkt.Get(latestFinalizedSlotKey); enc != nil {
+			headState.LatestFinalizedSlot = bytesutil.BytesToUint64BigEndian(enc)
+		} else {
+			log.Trace("Latest finalized slot number could not find in db. It may happen for brand new DB")
+		}
+		if enc := bkt.Get(latestFinalizedEpochKey); enc != nil {
+			headState.LatestFinalizedEpoch = bytesutil.BytesToUint64BigEndian(enc)
+		} else {
+			log.Trace("Latest finalized epoch number could not find in db. It may happen for brand new DB")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.head.Store(headState)
+	return nil
+}
+
+// updateHeadState applies update on a copy of the current head state, persists every marker in a single
+// transaction and then swaps the in-memory head state. Subscribers are notified after the swap.
+func (s *Store) updateHeadState(update func(headState *types.HeadState)) error {
+	s.headLock.Lock()
+	headState := s.headState().Copy()
+	update(headState)
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(latestInfoMarkerBucket)
+		if err := bkt.Put(lastStoredEpochKey, bytesutil.Uint64ToBytesBigEndian(headState.LatestEpoch)); err != nil {
+			return err
+		}
+		if err := bkt.Put(latestSavedVerifiedSlotKey, bytesutil.Uint64ToBytesBigEndian(headState.LatestVerifiedSlot)); err != nil {
+			return err
+		}
+		if err := bkt.Put(latestHeaderHashKey, headState.LatestVerifiedHeaderHash.Bytes()); err != nil {
+			return err
+		}
+		if err := bkt.Put(latestFinalizedSlotKey, bytesutil.Uint64ToBytesBigEndian(headState.LatestFinalizedSlot)); err != nil {
+			return err
+		}
+		return bkt.Put(latestFinalizedEpochKey, bytesutil.Uint64ToBytesBigEndian(headState.LatestFinalizedEpoch))
+	})
+	if err != nil {
+		s.headLock.Unlock()
+		return err
+	}
+	s.head.Store(headState)
+	s.headLock.Unlock()
+
+	s.headStateFeed.Send(headState.Copy())
+	return nil
+}
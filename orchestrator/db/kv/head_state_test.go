@@ -0,0 +1,74 @@
+package kv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_HeadState_LoadedOnOpen(t *testing.T) {
+	ctx := context.Background()
+	dbPath := t.TempDir()
+	db, err := NewKVStore(ctx, dbPath, &Config{})
+	require.NoError(t, err)
+
+	expected := &types.HeadState{
+		LatestEpoch:              3,
+		LatestVerifiedSlot:       100,
+		LatestVerifiedHeaderHash: common.HexToHash("0x0846da512db0a6888a59aa5f7235b741e36a9dcacc9dad33ee2a228878aefa74"),
+		LatestFinalizedSlot:      64,
+		LatestFinalizedEpoch:     2,
+	}
+	require.NoError(t, db.SaveLatestEpoch(ctx, expected.LatestEpoch))
+	require.NoError(t, db.SaveLatestVerifiedSlot(ctx, expected.LatestVerifiedSlot))
+	require.NoError(t, db.SaveLatestVerifiedHeaderHash(expected.LatestVerifiedHeaderHash))
+	require.NoError(t, db.SaveLatestFinalizedSlot(expected.LatestFinalizedSlot))
+	require.NoError(t, db.SaveLatestFinalizedEpoch(expected.LatestFinalizedEpoch))
+	assert.DeepEqual(t, expected, db.HeadState())
+	require.NoError(t, db.Close())
+
+	db, err = NewKVStore(ctx, dbPath, &Config{})
+	require.NoError(t, err)
+	defer db.Close()
+	assert.DeepEqual(t, expected, db.HeadState())
+}
+
+func TestStore_HeadState_Subscription(t *testing.T) {
+	db := setupDB(t)
+	headStateCh := make(chan *types.HeadState, 1)
+	sub := db.SubscribeHeadStateEvent(headStateCh)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, db.SaveLatestFinalizedSlot(32))
+	select {
+	case headState := <-headStateCh:
+		assert.Equal(t, uint64(32), headState.LatestFinalizedSlot)
+	case <-time.After(time.Second):
+		t.Fatal("head state event was not fired")
+	}
+
+	// mutating the published head state must not leak into the store
+	db.HeadState().LatestFinalizedSlot = 10
+	assert.Equal(t, uint64(32), db.LatestLatestFinalizedSlot())
+}
+
+func TestStore_UpdateVerifiedSlotInfo_SingleHeadChange(t *testing.T) {
+	ctx := context.Background()
+	db := setupReorgDB(t, ctx)
+	require.NoError(t, db.RemoveRangeVerifiedInfo(65, 100))
+
+	headStateCh := make(chan *types.HeadState, 2)
+	sub := db.SubscribeHeadStateEvent(headStateCh)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, db.UpdateVerifiedSlotInfo(64))
+	headState := <-headStateCh
+	assert.Equal(t, uint64(64), headState.LatestVerifiedSlot)
+	assert.Equal(t, common.BytesToHash([]byte{uint8(64 + 50)}), headState.LatestVerifiedHeaderHash)
+	assert.Equal(t, 0, len(headStateCh))
+}
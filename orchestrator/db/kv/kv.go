@@ -4,12 +4,14 @@ import (
 	"context"
 	"github.com/boltdb/bolt"
 	"github.com/dgraph-io/ristretto"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/params"
 	"github.com/pkg/errors"
 	"os"
 	"path"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -31,12 +33,17 @@ type Config struct {
 
 type Store struct {
 	ctx                   context.Context
-	isRunning             bool
 	db                    *bolt.DB
 	databasePath          string
 	consensusInfoCache    *ristretto.Cache
 	verifiedSlotInfoCache *ristretto.Cache
 
+	// head keeps the latest info markers in memory so that reads never touch the db
+	head          atomic.Value // *types.HeadState
+	headLock      sync.Mutex   // serializes head state updates
+	headStateFeed event.Feed
+	scope         event.SubscriptionScope
+
 	// There should be mutex in store
 	sync.Mutex
 }
@@ -102,20 +109,26 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 			verifiedSlotInfosBucket,
 			invalidSlotInfosBucket,
 			latestInfoMarkerBucket,
+			networkBucket,
+			pandoraHashIndexBucket,
+			vanguardHashIndexBucket,
 		)
 	}); err != nil {
 		return nil, err
 	}
 
-	latestFinalizedSlot := kv.LatestLatestFinalizedSlot()
-	latestFinalizedEpoch := kv.LatestLatestFinalizedEpoch()
-	latestVerifiedSlot := kv.LatestSavedVerifiedSlot()
-	latestVerifiedPanHeaderHash := kv.LatestVerifiedHeaderHash()
-	latestEpoch := kv.LatestSavedEpoch()
+	if err := kv.indexVerifiedSlotInfos(); err != nil {
+		return nil, err
+	}
+
+	if err := kv.loadHeadState(); err != nil {
+		return nil, err
+	}
 
-	log.WithField("latestFinalizedSlot", latestFinalizedSlot).WithField("latestFinalizedEpoch", latestFinalizedEpoch).
-		WithField("latestVerifiedSlot", latestVerifiedSlot).WithField("latestVerifiedPanHeaderHash", latestVerifiedPanHeaderHash).
-		WithField("latestEpoch", latestEpoch).Info("Initial saved latest infos")
+	headState := kv.headState()
+	log.WithField("latestFinalizedSlot", headState.LatestFinalizedSlot).WithField("latestFinalizedEpoch", headState.LatestFinalizedEpoch).
+		WithField("latestVerifiedSlot", headState.LatestVerifiedSlot).WithField("latestVerifiedPanHeaderHash", headState.LatestVerifiedHeaderHash).
+		WithField("latestEpoch", headState.LatestEpoch).Info("Initial saved latest infos")
 
 	return kv, err
 }
@@ -134,6 +147,7 @@ func (s *Store) ClearDB() error {
 // Close closes the underlying BoltDB database.
 func (s *Store) Close() error {
 	log.Info("Received cancelled context, closing db")
+	s.scope.Close()
 	return s.db.Close()
 }
 
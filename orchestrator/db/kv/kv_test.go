@@ -6,28 +6,24 @@ import (
 	"testing"
 )
 
-// setupDB instantiates and returns a Store instance.
-func setupDB(t testing.TB, useTempDir bool) *Store {
-	var dbPath string
-	if !useTempDir {
-		dbPath = "./testdata/" + OrchestratorNodeDbDirName
-	} else {
-		dbPath = t.TempDir()
-	}
-	db, err := NewKVStore(context.Background(), dbPath, &Config{})
+// setupDB instantiates and returns a Store instance in a temporary directory.
+func setupDB(t testing.TB) *Store {
+	db, err := NewKVStore(context.Background(), t.TempDir(), &Config{})
 	require.NoError(t, err, "Failed to instantiate DB")
-	if useTempDir {
-		t.Cleanup(func() {
-			require.NoError(t, db.Close(), "Failed to close database")
-		})
-	}
+	t.Cleanup(func() {
+		require.NoError(t, db.Close(), "Failed to close database")
+	})
 	return db
 }
 
 func TestKV_Start_Stop(t *testing.T) {
-	kv := setupDB(t, false)
-	defer kv.ClearDB()
+	dbPath := t.TempDir()
+	kv, err := NewKVStore(context.Background(), dbPath, &Config{})
+	require.NoError(t, err, "Failed to instantiate DB")
+	require.NoError(t, kv.Close())
 
+	// the same database can be opened again once closed
+	kv, err = NewKVStore(context.Background(), dbPath, &Config{})
+	require.NoError(t, err, "Failed to reopen DB")
 	require.NoError(t, kv.Close())
-	kv = setupDB(t, false)
 }
@@ -0,0 +1,74 @@
+package kv
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// PandoraNetwork returns the pinned pandora network or nil when none is pinned yet.
+func (s *Store) PandoraNetwork() (*types.PandoraNetwork, error) {
+	var network *types.PandoraNetwork
+	err := s.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(networkBucket).Get(pandoraNetworkKey)
+		if value == nil {
+			return nil
+		}
+		return decode(value, &network)
+	})
+	return network, err
+}
+
+// PinPandoraNetwork pins the given pandora network unless one is pinned already and returns the pinned one
+func (s *Store) PinPandoraNetwork(network *types.PandoraNetwork) (*types.PandoraNetwork, error) {
+	pinned := new(types.PandoraNetwork)
+	if err := s.pinNetwork(pandoraNetworkKey, network, pinned); err != nil {
+		return nil, err
+	}
+	return pinned, nil
+}
+
+// VanguardNetwork returns the pinned vanguard network or nil when none is pinned yet.
+func (s *Store) VanguardNetwork() (*types.VanguardNetwork, error) {
+	var network *types.VanguardNetwork
+	err := s.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(networkBucket).Get(vanguardNetworkKey)
+		if value == nil {
+			return nil
+		}
+		return decode(value, &network)
+	})
+	return network, err
+}
+
+// PinVanguardNetwork pins the given vanguard network unless one is pinned already and returns the pinned one
+func (s *Store) PinVanguardNetwork(network *types.VanguardNetwork) (*types.VanguardNetwork, error) {
+	pinned := new(types.VanguardNetwork)
+	if err := s.pinNetwork(vanguardNetworkKey, network, pinned); err != nil {
+		return nil, err
+	}
+	return pinned, nil
+}
+
+// pinNetwork stores the network under the given key when the key is absent and decodes the stored network
+// into pinned. Checking and storing within one transaction keeps concurrent connections from pinning
+// different networks.
+func (s *Store) pinNetwork(key []byte, network, pinned interface{}) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(networkBucket)
+		value := bkt.Get(key)
+		if value == nil {
+			enc, err := encode(network)
+			if err != nil {
+				return err
+			}
+			if err := bkt.Put(key, enc); err != nil {
+				return err
+			}
+			value = enc
+		}
+		return decode(value, pinned)
+	})
+}
@@ -0,0 +1,79 @@
+package kv
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_Network_PersistedOnReopen(t *testing.T) {
+	ctx := context.Background()
+	dbPath := t.TempDir()
+	db, err := NewKVStore(ctx, dbPath, &Config{})
+	require.NoError(t, err)
+
+	pandoraNetwork, err := db.PandoraNetwork()
+	require.NoError(t, err)
+	assert.Equal(t, (*types.PandoraNetwork)(nil), pandoraNetwork)
+	vanguardNetwork, err := db.VanguardNetwork()
+	require.NoError(t, err)
+	assert.Equal(t, (*types.VanguardNetwork)(nil), vanguardNetwork)
+
+	expectedPandora := &types.PandoraNetwork{
+		ChainID:     4004181,
+		GenesisHash: common.HexToHash("0x0846da512db0a6888a59aa5f7235b741e36a9dcacc9dad33ee2a228878aefa74"),
+	}
+	expectedVanguard := &types.VanguardNetwork{
+		GenesisTime:           1624266000,
+		GenesisValidatorsRoot: common.HexToHash("0x2ab8c1b4f5ef9a1b8e5b5d3ccf6dfd4fe4bd7dc2e8d3b5e1c1dfb4e0a6d2c8f1"),
+		DepositContract:       common.HexToAddress("0x000000000000000000000000000000000000cafe"),
+	}
+	pandoraNetwork, err = db.PinPandoraNetwork(expectedPandora)
+	require.NoError(t, err)
+	assert.DeepEqual(t, expectedPandora, pandoraNetwork)
+	vanguardNetwork, err = db.PinVanguardNetwork(expectedVanguard)
+	require.NoError(t, err)
+	assert.DeepEqual(t, expectedVanguard, vanguardNetwork)
+	require.NoError(t, db.Close())
+
+	db, err = NewKVStore(ctx, dbPath, &Config{})
+	require.NoError(t, err)
+	defer db.Close()
+	pandoraNetwork, err = db.PandoraNetwork()
+	require.NoError(t, err)
+	assert.DeepEqual(t, expectedPandora, pandoraNetwork)
+	vanguardNetwork, err = db.VanguardNetwork()
+	require.NoError(t, err)
+	assert.DeepEqual(t, expectedVanguard, vanguardNetwork)
+}
+
+func TestStore_PinNetwork_Concurrent(t *testing.T) {
+	db, err := NewKVStore(context.Background(), t.TempDir(), &Config{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	// concurrent connections of different networks all end up with the first pinned one
+	pinned := make([]*types.PandoraNetwork, 8)
+	var wg sync.WaitGroup
+	for i := range pinned {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			network, err := db.PinPandoraNetwork(&types.PandoraNetwork{ChainID: uint64(i + 1)})
+			assert.NoError(t, err)
+			pinned[i] = network
+		}(i)
+	}
+	wg.Wait()
+
+	stored, err := db.PandoraNetwork()
+	require.NoError(t, err)
+	for _, network := range pinned {
+		assert.DeepEqual(t, stored, network)
+	}
+}
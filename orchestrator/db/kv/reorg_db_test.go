@@ -10,7 +10,7 @@ import (
 )
 
 func setupReorgDB(t *testing.T, ctx context.Context) *Store {
-	db := setupDB(t, true)
+	db := setupDB(t)
 	for i := 0; i < 5; i++ {
 		consensusInfo := testutil.NewMinimalConsensusInfo(uint64(i))
 		epochInfoV2 := consensusInfo.ConvertToEpochInfo()
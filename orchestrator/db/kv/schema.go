@@ -6,10 +6,19 @@ var (
 	verifiedSlotInfosBucket = []byte("verified-slots")
 	invalidSlotInfosBucket  = []byte("invalid-slots")
 	latestInfoMarkerBucket  = []byte("latest-info-marker") // Only use for storing the following keys
+	networkBucket           = []byte("network")            // pinned pandora and vanguard networks
+
+	// verified slot of every pandora header hash and vanguard block hash
+	pandoraHashIndexBucket  = []byte("pandora-hash-index")
+	vanguardHashIndexBucket = []byte("vanguard-hash-index")
 
 	latestHeaderHashKey        = []byte("latest-header-hash")
 	lastStoredEpochKey         = []byte("last-epoch")
 	latestSavedVerifiedSlotKey = []byte("latest-verified-slot")
 	latestFinalizedSlotKey     = []byte("latest-finalized-slot")
 	latestFinalizedEpochKey    = []byte("latest-finalized-epoch")
+	vanguardChainHeadKey       = []byte("vanguard-chain-head")
+
+	pandoraNetworkKey  = []byte("pandora")
+	vanguardNetworkKey = []byte("vanguard")
 )
@@ -1,6 +1,7 @@
 package kv
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
@@ -98,6 +99,33 @@ func (s *Store) VerifiedSlotInfos(fromSlot uint64) (map[uint64]*types.SlotInfo,
 	return slotInfos, nil
 }
 
+// VerifiedSlotByPandoraHash returns the verified slot of the given pandora header hash
+func (s *Store) VerifiedSlotByPandoraHash(hash common.Hash) (uint64, bool, error) {
+	return s.indexedSlot(pandoraHashIndexBucket, hash)
+}
+
+// VerifiedSlotByVanguardHash returns the verified slot of the given vanguard block hash
+func (s *Store) VerifiedSlotByVanguardHash(hash common.Hash) (uint64, bool, error) {
+	return s.indexedSlot(vanguardHashIndexBucket, hash)
+}
+
+// indexedSlot looks up the slot of a hash in one of the hash index buckets
+func (s *Store) indexedSlot(bucket []byte, hash common.Hash) (uint64, bool, error) {
+	var (
+		slot  uint64
+		found bool
+	)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(bucket).Get(hash.Bytes())
+		if value == nil {
+			return nil
+		}
+		slot, found = bytesutil.BytesToUint64BigEndian(value), true
+		return nil
+	})
+	return slot, found, err
+}
+
 // SaveVerifiedSlotInfo will insert slot information to particular slot to db and cache
 // After save operations you must call SaveLatestVerifiedSlot to push in memory slot height to db
 func (s *Store) SaveVerifiedSlotInfo(slot uint64, slotInfo *types.SlotInfo) error {
@@ -109,85 +137,98 @@ func (s *Store) SaveVerifiedSlotInfo(slot uint64, slotInfo *types.SlotInfo) erro
 		if err != nil {
 			return err
 		}
+		// the hashes of an overwritten slot info must not point to this slot anymore
+		if err := unindexSlotInfo(tx, slotBytes); err != nil {
+			return err
+		}
 		if status := s.verifiedSlotInfoCache.Set(slot, slotInfo, 0); !status {
 			log.WithField("slot", slot).Warn("could not store verified slot info into cache")
 		}
 		if err := bkt.Put(slotBytes, enc); err != nil {
 			return err
 		}
-		return nil
+		return indexSlotInfo(tx, slotBytes, slotInfo)
 	})
 }
 
-// SaveLatestEpoch
-func (s *Store) SaveLatestVerifiedSlot(ctx context.Context, slot uint64) error {
-	// storing latest epoch number into db
+// indexSlotInfo points the pandora and vanguard hashes of a slot info to its slot
+func indexSlotInfo(tx *bolt.Tx, slotBytes []byte, slotInfo *types.SlotInfo) error {
+	if err := tx.Bucket(pandoraHashIndexBucket).Put(slotInfo.PandoraHeaderHash.Bytes(), slotBytes); err != nil {
+		return err
+	}
+	return tx.Bucket(vanguardHashIndexBucket).Put(slotInfo.VanguardBlockHash.Bytes(), slotBytes)
+}
+
+// unindexSlotInfo removes the index entries of the slot info stored at the given slot. Entries which
+// were taken over by another slot are kept.
+func unindexSlotInfo(tx *bolt.Tx, slotBytes []byte) error {
+	enc := tx.Bucket(verifiedSlotInfosBucket).Get(slotBytes)
+	if enc == nil {
+		return nil
+	}
+	var slotInfo *types.SlotInfo
+	if err := decode(enc, &slotInfo); err != nil {
+		return err
+	}
+	if err := unindexHash(tx.Bucket(pandoraHashIndexBucket), slotInfo.PandoraHeaderHash, slotBytes); err != nil {
+		return err
+	}
+	return unindexHash(tx.Bucket(vanguardHashIndexBucket), slotInfo.VanguardBlockHash, slotBytes)
+}
+
+// unindexHash removes the index entry of a hash when it still points to the given slot
+func unindexHash(bkt *bolt.Bucket, hash common.Hash, slotBytes []byte) error {
+	if !bytes.Equal(bkt.Get(hash.Bytes()), slotBytes) {
+		return nil
+	}
+	return bkt.Delete(hash.Bytes())
+}
+
+// indexVerifiedSlotInfos fills the hash indexes of databases which were written before the indexes existed
+func (s *Store) indexVerifiedSlotInfos() error {
 	return s.db.Update(func(tx *bolt.Tx) error {
-		bkt := tx.Bucket(latestInfoMarkerBucket)
-		slotBytes := bytesutil.Uint64ToBytesBigEndian(slot)
-		if err := bkt.Put(latestSavedVerifiedSlotKey, slotBytes); err != nil {
-			return err
+		if key, _ := tx.Bucket(pandoraHashIndexBucket).Cursor().First(); key != nil {
+			return nil
 		}
-		return nil
+		indexed := 0
+		err := tx.Bucket(verifiedSlotInfosBucket).ForEach(func(slotBytes, enc []byte) error {
+			var slotInfo *types.SlotInfo
+			if err := decode(enc, &slotInfo); err != nil {
+				return err
+			}
+			indexed++
+			return indexSlotInfo(tx, slotBytes, slotInfo)
+		})
+		if indexed > 0 {
+			log.WithField("slots", indexed).Info("Indexed hashes of verified slot infos")
+		}
+		return err
 	})
 }
 
-// LatestSavedEpoch
+// SaveLatestVerifiedSlot
+func (s *Store) SaveLatestVerifiedSlot(ctx context.Context, slot uint64) error {
+	return s.updateHeadState(func(headState *types.HeadState) {
+		headState.LatestVerifiedSlot = slot
+	})
+}
+
+// LatestSavedVerifiedSlot
 func (s *Store) LatestSavedVerifiedSlot() uint64 {
-	var latestSavedVerifiedSlot uint64
-	// Db is not prepared yet. Retrieve latest saved epoch number from db
-	if !s.isRunning {
-		s.db.View(func(tx *bolt.Tx) error {
-			bkt := tx.Bucket(latestInfoMarkerBucket)
-			slotBytes := bkt.Get(latestSavedVerifiedSlotKey[:])
-			// not found the latest epoch in db. so latest epoch will be zero
-			if slotBytes == nil {
-				latestSavedVerifiedSlot = uint64(0)
-				log.Trace("Latest verified slot number could not find in db. It may happen for brand new DB")
-				return nil
-			}
-			latestSavedVerifiedSlot = bytesutil.BytesToUint64BigEndian(slotBytes)
-			return nil
-		})
-	}
-	// db is already started so latest epoch must be initialized in store
-	return latestSavedVerifiedSlot
+	return s.headState().LatestVerifiedSlot
 }
 
-// SaveLatestEpoch
+// SaveLatestVerifiedHeaderHash
 func (s *Store) SaveLatestVerifiedHeaderHash(hash common.Hash) error {
-	// storing latest epoch number into db
-	return s.db.Update(func(tx *bolt.Tx) error {
-		bkt := tx.Bucket(latestInfoMarkerBucket)
-		headerHashBytes := hash.Bytes()
-		if err := bkt.Put(latestHeaderHashKey, headerHashBytes); err != nil {
-			return err
-		}
-		return nil
+	return s.updateHeadState(func(headState *types.HeadState) {
+		headState.LatestVerifiedHeaderHash = hash
 	})
 }
 
 // LatestVerifiedHeaderHash should return latest verified header hash but I really dont know which (pandora or vanguard?)
 // It should say explicitly which hash its returning, it looks like its pandora hash
 func (s *Store) LatestVerifiedHeaderHash() common.Hash {
-	var latestHeaderHash common.Hash
-	// Db is not prepared yet. Retrieve latest saved epoch number from db
-	if !s.isRunning {
-		s.db.View(func(tx *bolt.Tx) error {
-			bkt := tx.Bucket(latestInfoMarkerBucket)
-			latestHeaderHashBytes := bkt.Get(latestHeaderHashKey[:])
-			// not found the latest epoch in db. so latest epoch will be zero
-			if latestHeaderHashBytes == nil {
-				latestHeaderHash = EmptyHash
-				log.Trace("Latest verified header hash could not find in db. Brand new DB.")
-				return nil
-			}
-			latestHeaderHash = common.BytesToHash(latestHeaderHashBytes)
-			return nil
-		})
-	}
-	// db is already started so latest epoch must be initialized in store
-	return latestHeaderHash
+	return s.headState().LatestVerifiedHeaderHash
 }
 
 // FindVerifiedSlotNumber will try to find matching of verified slot info
@@ -219,6 +260,9 @@ func (s *Store) RemoveRangeVerifiedInfo(fromSlot, toSlot uint64) error {
 		for slotNum := fromSlot; slotNum <= toSlot; slotNum++ {
 			removingSlotNumber := bytesutil.Uint64ToBytesBigEndian(slotNum)
 			s.verifiedSlotInfoCache.Del(slotNum)
+			if err := unindexSlotInfo(tx, removingSlotNumber); err != nil {
+				return err
+			}
 			err := bkt.Delete(removingSlotNumber)
 			if err != nil {
 				return err
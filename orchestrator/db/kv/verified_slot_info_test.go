@@ -2,6 +2,7 @@ package kv
 
 import (
 	"context"
+	"github.com/boltdb/bolt"
 	"github.com/ethereum/go-ethereum/common"
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
@@ -11,7 +12,7 @@ import (
 )
 
 func TestStore_VerifiedSlotInfo(t *testing.T) {
-	db := setupDB(t, true)
+	db := setupDB(t)
 	slotInfosLen := 32
 	slotInfos := createAndSaveEmptySlotInfos(t, slotInfosLen, db)
 	retrievedSlotInfo, err := db.VerifiedSlotInfo(0)
@@ -20,7 +21,7 @@ func TestStore_VerifiedSlotInfo(t *testing.T) {
 }
 
 func TestStore_VerifiedSlotInfos(t *testing.T) {
-	db := setupDB(t, true)
+	db := setupDB(t)
 	slotInfosLen := 64
 	slotInfos := createAndSaveEmptySlotInfos(t, slotInfosLen, db)
 	require.NoError(t, db.SaveLatestVerifiedSlot(context.Background(), uint64(slotInfosLen)-uint64(1)))
@@ -31,7 +32,7 @@ func TestStore_VerifiedSlotInfos(t *testing.T) {
 }
 
 func TestStore_LatestVerifiedSuite(t *testing.T) {
-	db := setupDB(t, true)
+	db := setupDB(t)
 	ctx := context.Background()
 	createAndSaveEmptySlotInfos(t, 64, db)
 	customSlotInfoHeight := uint64(64)
@@ -49,7 +50,7 @@ func TestStore_LatestVerifiedSuite(t *testing.T) {
 }
 
 func TestStore_FindVerifiedSlotNumber(t *testing.T) {
-	db := setupDB(t, true)
+	db := setupDB(t)
 	ctx := context.Background()
 	slotInfos := createAndSaveEmptySlotInfos(t, 64, db)
 	customSlotInfoHeight := uint64(64)
@@ -80,6 +81,64 @@ func TestStore_FindVerifiedSlotNumber(t *testing.T) {
 	})
 }
 
+func TestStore_VerifiedSlotByHash(t *testing.T) {
+	db := setupDB(t)
+	slotInfo := &types.SlotInfo{
+		VanguardBlockHash: common.HexToHash("0x6f701e4e8b260f38a43cdc0d97cfdc7f0cd33f58ef26bbc6c327ac87d76304d2"),
+		PandoraHeaderHash: common.HexToHash("0x0846da512db0a6888a59aa5f7235b741e36a9dcacc9dad33ee2a228878aefa74"),
+	}
+	require.NoError(t, db.SaveVerifiedSlotInfo(10, slotInfo))
+
+	slot, found, err := db.VerifiedSlotByPandoraHash(slotInfo.PandoraHeaderHash)
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(10), slot)
+	slot, found, err = db.VerifiedSlotByVanguardHash(slotInfo.VanguardBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(10), slot)
+
+	// overwriting the slot drops the previous hashes
+	require.NoError(t, db.SaveVerifiedSlotInfo(10, &types.SlotInfo{}))
+	_, found, err = db.VerifiedSlotByPandoraHash(slotInfo.PandoraHeaderHash)
+	require.NoError(t, err)
+	assert.Equal(t, false, found)
+
+	require.NoError(t, db.SaveVerifiedSlotInfo(11, slotInfo))
+	require.NoError(t, db.RemoveRangeVerifiedInfo(11, 11))
+	_, found, err = db.VerifiedSlotByVanguardHash(slotInfo.VanguardBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, false, found)
+}
+
+func TestStore_IndexVerifiedSlotInfos(t *testing.T) {
+	db := setupDB(t)
+	slotInfo := &types.SlotInfo{
+		VanguardBlockHash: common.HexToHash("0x6f701e4e8b260f38a43cdc0d97cfdc7f0cd33f58ef26bbc6c327ac87d76304d2"),
+		PandoraHeaderHash: common.HexToHash("0x0846da512db0a6888a59aa5f7235b741e36a9dcacc9dad33ee2a228878aefa74"),
+	}
+	require.NoError(t, db.SaveVerifiedSlotInfo(7, slotInfo))
+	// simulate a database written before the hash indexes existed
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(pandoraHashIndexBucket); err != nil {
+			return err
+		}
+		if err := tx.DeleteBucket(vanguardHashIndexBucket); err != nil {
+			return err
+		}
+		return createBuckets(tx, pandoraHashIndexBucket, vanguardHashIndexBucket)
+	}))
+	_, found, err := db.VerifiedSlotByPandoraHash(slotInfo.PandoraHeaderHash)
+	require.NoError(t, err)
+	require.Equal(t, false, found)
+
+	require.NoError(t, db.indexVerifiedSlotInfos())
+	slot, found, err := db.VerifiedSlotByPandoraHash(slotInfo.PandoraHeaderHash)
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(7), slot)
+}
+
 func createAndSaveEmptySlotInfos(t *testing.T, slotsLen int, db *Store) (slotInfos []*types.SlotInfo) {
 	slotInfos = make([]*types.SlotInfo, slotsLen)
 
@@ -0,0 +1,27 @@
+package memorydb
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// VanguardChainHead returns the latest saved vanguard chain head or nil when none is saved yet.
+func (s *Store) VanguardChainHead() (*types.VanguardChainHead, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.vanguardChainHead == nil {
+		return nil, nil
+	}
+	cpy := *s.vanguardChainHead
+	return &cpy, nil
+}
+
+// SaveVanguardChainHead saves the latest vanguard chain head
+func (s *Store) SaveVanguardChainHead(chainHead *types.VanguardChainHead) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	cpy := *chainHead
+	s.vanguardChainHead = &cpy
+	return nil
+}
@@ -0,0 +1,33 @@
+package memorydb
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_VanguardChainHead(t *testing.T) {
+	db := setupDB(t)
+	chainHead, err := db.VanguardChainHead()
+	require.NoError(t, err)
+	assert.Equal(t, (*types.VanguardChainHead)(nil), chainHead)
+
+	expected := &types.VanguardChainHead{HeadSlot: 70, JustifiedEpoch: 2, FinalizedSlot: 32, FinalizedEpoch: 1}
+	require.NoError(t, db.SaveVanguardChainHead(expected))
+	chainHead, err = db.VanguardChainHead()
+	require.NoError(t, err)
+	assert.DeepEqual(t, expected, chainHead)
+
+	// stored chain heads can not be mutated by the callers
+	chainHead.HeadSlot = 1
+	chainHead, err = db.VanguardChainHead()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(70), chainHead.HeadSlot)
+
+	require.NoError(t, db.ClearDB())
+	chainHead, err = db.VanguardChainHead()
+	require.NoError(t, err)
+	assert.Equal(t, (*types.VanguardChainHead)(nil), chainHead)
+}
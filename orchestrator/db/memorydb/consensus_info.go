@@ -0,0 +1,76 @@
+package memorydb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+var errInvalidEpoch = errors.New("invalid epoch and not found any consensusInfo for the given epoch")
+
+// ConsensusInfo returns the consensus info of the given epoch or nil when it is not stored.
+func (s *Store) ConsensusInfo(ctx context.Context, epoch uint64) (*types.MinimalEpochConsensusInfo, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return copyConsensusInfo(s.consensusInfos[epoch]), nil
+}
+
+// ConsensusInfos returns consensus infos from fromEpoch up to the latest saved epoch. Like the kv store,
+// it stops at the first missing epoch.
+func (s *Store) ConsensusInfos(fromEpoch uint64) ([]*types.MinimalEpochConsensusInfo, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	// when requested epoch is greater than stored latest epoch
+	if fromEpoch > s.head.LatestEpoch {
+		return nil, errors.Wrap(errInvalidEpoch, fmt.Sprintf("fromEpoch: %d", fromEpoch))
+	}
+
+	consensusInfos := make([]*types.MinimalEpochConsensusInfo, 0)
+	for epoch := fromEpoch; epoch <= s.head.LatestEpoch; epoch++ {
+		consensusInfo, ok := s.consensusInfos[epoch]
+		if !ok {
+			break
+		}
+		consensusInfos = append(consensusInfos, copyConsensusInfo(consensusInfo))
+	}
+	return consensusInfos, nil
+}
+
+// SaveConsensusInfo stores the consensus info under its epoch.
+func (s *Store) SaveConsensusInfo(ctx context.Context, consensusInfo *types.MinimalEpochConsensusInfo) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.consensusInfos[consensusInfo.Epoch] = copyConsensusInfo(consensusInfo)
+	return nil
+}
+
+// RemoveRangeConsensusInfo deletes consensus infos in [startEpoch, endEpoch]
+func (s *Store) RemoveRangeConsensusInfo(startEpoch, endEpoch uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		delete(s.consensusInfos, epoch)
+	}
+	return nil
+}
+
+// LatestSavedEpoch
+func (s *Store) LatestSavedEpoch() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.head.LatestEpoch
+}
+
+// SaveLatestEpoch
+func (s *Store) SaveLatestEpoch(ctx context.Context, epoch uint64) error {
+	return s.updateHeadState(func(headState *types.HeadState) {
+		headState.LatestEpoch = epoch
+	})
+}
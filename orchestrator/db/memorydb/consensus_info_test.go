@@ -0,0 +1,62 @@
+package memorydb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	eventTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_ConsensusInfo_RetrieveByEpoch(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	totalConsensusInfos := make([]*eventTypes.MinimalEpochConsensusInfo, 50)
+	for i := 0; i < 50; i++ {
+		totalConsensusInfos[i] = testutil.NewMinimalConsensusInfo(uint64(i)).ConvertToEpochInfo()
+		require.NoError(t, db.SaveConsensusInfo(ctx, totalConsensusInfos[i]))
+	}
+
+	retrievedConsensusInfo, err := db.ConsensusInfo(ctx, 49)
+	require.NoError(t, err)
+	assert.DeepEqual(t, totalConsensusInfos[49], retrievedConsensusInfo)
+
+	retrievedConsensusInfo, err = db.ConsensusInfo(ctx, 50)
+	require.NoError(t, err)
+	assert.Equal(t, (*eventTypes.MinimalEpochConsensusInfo)(nil), retrievedConsensusInfo)
+}
+
+func TestStore_ConsensusInfos_RetrieveByEpoch(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	require.NoError(t, db.SaveLatestEpoch(ctx, 199))
+	totalConsensusInfos := make([]*eventTypes.MinimalEpochConsensusInfo, 200)
+	for i := 0; i < 200; i++ {
+		totalConsensusInfos[i] = testutil.NewMinimalConsensusInfo(uint64(i)).ConvertToEpochInfo()
+		require.NoError(t, db.SaveConsensusInfo(ctx, totalConsensusInfos[i]))
+	}
+
+	retrievedConsensusInfos, err := db.ConsensusInfos(10)
+	require.NoError(t, err)
+	assert.DeepEqual(t, totalConsensusInfos[10:], retrievedConsensusInfos)
+
+	_, err = db.ConsensusInfos(200)
+	require.ErrorContains(t, errInvalidEpoch.Error(), err)
+}
+
+func TestStore_RemoveRangeConsensusInfo(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, db.SaveConsensusInfo(ctx, testutil.NewMinimalConsensusInfo(uint64(i)).ConvertToEpochInfo()))
+	}
+	require.NoError(t, db.SaveLatestEpoch(ctx, 9))
+	require.NoError(t, db.RemoveRangeConsensusInfo(5, 9))
+
+	// retrieval stops at the first missing epoch same as the kv store
+	retrievedConsensusInfos, err := db.ConsensusInfos(0)
+	require.NoError(t, err)
+	assert.Equal(t, 5, len(retrievedConsensusInfos))
+}
@@ -0,0 +1,30 @@
+package memorydb
+
+import (
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// HeadState returns a copy of the latest info markers.
+func (s *Store) HeadState() *types.HeadState {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.head.Copy()
+}
+
+// SubscribeHeadStateEvent registers a subscription which fires whenever any latest info marker changes.
+func (s *Store) SubscribeHeadStateEvent(ch chan<- *types.HeadState) event.Subscription {
+	return s.scope.Track(s.headStateFeed.Subscribe(ch))
+}
+
+// updateHeadState applies update on the head state and notifies subscribers afterwards.
+func (s *Store) updateHeadState(update func(headState *types.HeadState)) error {
+	s.lock.Lock()
+	update(s.head)
+	headState := s.head.Copy()
+	s.lock.Unlock()
+
+	s.headStateFeed.Send(headState)
+	return nil
+}
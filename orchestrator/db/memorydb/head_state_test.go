@@ -0,0 +1,26 @@
+package memorydb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_HeadState_Subscription(t *testing.T) {
+	db := setupDB(t)
+	headStateCh := make(chan *types.HeadState, 1)
+	sub := db.SubscribeHeadStateEvent(headStateCh)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, db.SaveLatestFinalizedEpoch(2))
+	select {
+	case headState := <-headStateCh:
+		assert.Equal(t, uint64(2), headState.LatestFinalizedEpoch)
+	case <-time.After(time.Second):
+		t.Fatal("head state event was not fired")
+	}
+	assert.Equal(t, uint64(2), db.HeadState().LatestFinalizedEpoch)
+}
@@ -0,0 +1,5 @@
+package memorydb
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "memorydb")
@@ -0,0 +1,103 @@
+// Package memorydb implements the orchestrator database entirely in memory. It mirrors the
+// semantics of the bolt backed kv store and is meant for tests and throwaway (ephemeral) nodes.
+package memorydb
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// Store keeps every bucket of the orchestrator database in plain maps guarded by a lock.
+type Store struct {
+	ctx context.Context
+
+	consensusInfos    map[uint64]*types.MinimalEpochConsensusInfo
+	verifiedSlotInfos map[uint64]*types.SlotInfo
+	invalidSlotInfos  map[uint64]*types.SlotInfo
+
+	// verified slot of every pandora header hash and vanguard block hash
+	pandoraHashIndex  map[common.Hash]uint64
+	vanguardHashIndex map[common.Hash]uint64
+
+	// pinned networks
+	pandoraNetwork  *types.PandoraNetwork
+	vanguardNetwork *types.VanguardNetwork
+
+	// latest known vanguard chain head
+	vanguardChainHead *types.VanguardChainHead
+
+	// latest info markers
+	head          *types.HeadState
+	headStateFeed event.Feed
+	scope         event.SubscriptionScope
+
+	lock sync.RWMutex
+}
+
+// NewStore initializes a new, empty in-memory store.
+func NewStore(ctx context.Context) *Store {
+	s := &Store{ctx: ctx}
+	s.reset()
+	log.Info("Initialized in-memory database, no data will be persisted")
+	return s
+}
+
+// reset drops every stored item and marker. The caller must hold the lock or own the store exclusively.
+func (s *Store) reset() {
+	s.consensusInfos = make(map[uint64]*types.MinimalEpochConsensusInfo)
+	s.verifiedSlotInfos = make(map[uint64]*types.SlotInfo)
+	s.invalidSlotInfos = make(map[uint64]*types.SlotInfo)
+	s.pandoraHashIndex = make(map[common.Hash]uint64)
+	s.vanguardHashIndex = make(map[common.Hash]uint64)
+	s.pandoraNetwork = nil
+	s.vanguardNetwork = nil
+	s.vanguardChainHead = nil
+	s.head = &types.HeadState{LatestVerifiedHeaderHash: EmptyHash}
+}
+
+// ClearDB removes every stored item from memory.
+func (s *Store) ClearDB() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.reset()
+	return nil
+}
+
+// Close is a no-op for the in-memory store. Stored data stays available until the store is dropped.
+func (s *Store) Close() error {
+	log.Info("Received cancelled context, closing in-memory db")
+	s.scope.Close()
+	return nil
+}
+
+// DatabasePath returns an empty path since nothing is written to disk.
+func (s *Store) DatabasePath() string {
+	return ""
+}
+
+// copySlotInfo returns a copy of the given slot info so that callers can not mutate stored values.
+func copySlotInfo(slotInfo *types.SlotInfo) *types.SlotInfo {
+	if slotInfo == nil {
+		return nil
+	}
+	cpy := *slotInfo
+	return &cpy
+}
+
+// copyConsensusInfo returns a deep copy of the given consensus info.
+func copyConsensusInfo(consensusInfo *types.MinimalEpochConsensusInfo) *types.MinimalEpochConsensusInfo {
+	if consensusInfo == nil {
+		return nil
+	}
+	cpy := *consensusInfo
+	if consensusInfo.ValidatorList != nil {
+		cpy.ValidatorList = make([]string, len(consensusInfo.ValidatorList))
+		copy(cpy.ValidatorList, consensusInfo.ValidatorList)
+	}
+	return &cpy
+}
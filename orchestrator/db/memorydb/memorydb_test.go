@@ -0,0 +1,70 @@
+package memorydb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// setupDB instantiates and returns an in-memory Store instance.
+func setupDB(t testing.TB) *Store {
+	db := NewStore(context.Background())
+	t.Cleanup(func() {
+		require.NoError(t, db.Close(), "Failed to close database")
+	})
+	return db
+}
+
+func TestStore_ClearDB(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	require.NoError(t, db.SaveConsensusInfo(ctx, testutil.NewMinimalConsensusInfo(1).ConvertToEpochInfo()))
+	require.NoError(t, db.SaveLatestEpoch(ctx, 1))
+	require.NoError(t, db.SaveVerifiedSlotInfo(10, &types.SlotInfo{PandoraHeaderHash: common.HexToHash("0x01")}))
+	require.NoError(t, db.SaveLatestVerifiedSlot(ctx, 10))
+	require.NoError(t, db.SaveLatestVerifiedHeaderHash(common.HexToHash("0x01")))
+	require.NoError(t, db.SaveLatestFinalizedSlot(5))
+	require.NoError(t, db.SaveLatestFinalizedEpoch(1))
+
+	require.NoError(t, db.ClearDB())
+
+	consensusInfo, err := db.ConsensusInfo(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.MinimalEpochConsensusInfo)(nil), consensusInfo)
+	slotInfo, err := db.VerifiedSlotInfo(10)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.SlotInfo)(nil), slotInfo)
+	assert.Equal(t, uint64(0), db.LatestSavedEpoch())
+	assert.Equal(t, uint64(0), db.LatestSavedVerifiedSlot())
+	assert.Equal(t, EmptyHash, db.LatestVerifiedHeaderHash())
+	assert.Equal(t, uint64(0), db.LatestLatestFinalizedSlot())
+	assert.Equal(t, uint64(0), db.LatestLatestFinalizedEpoch())
+	assert.Equal(t, "", db.DatabasePath())
+}
+
+func TestStore_StoredValuesAreCopied(t *testing.T) {
+	db := setupDB(t)
+	slotInfo := &types.SlotInfo{
+		VanguardBlockHash: common.HexToHash("0x01"),
+		PandoraHeaderHash: common.HexToHash("0x02"),
+	}
+	require.NoError(t, db.SaveVerifiedSlotInfo(1, slotInfo))
+
+	// mutating the saved value must not leak into the store
+	slotInfo.PandoraHeaderHash = common.HexToHash("0x03")
+	retrievedSlotInfo, err := db.VerifiedSlotInfo(1)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0x02"), retrievedSlotInfo.PandoraHeaderHash)
+
+	// mutating the retrieved value must not leak into the store either
+	retrievedSlotInfo.VanguardBlockHash = common.HexToHash("0x04")
+	retrievedSlotInfo, err = db.VerifiedSlotInfo(1)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0x01"), retrievedSlotInfo.VanguardBlockHash)
+}
@@ -0,0 +1,55 @@
+package memorydb
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// PandoraNetwork returns the pinned pandora network or nil when none is pinned yet.
+func (s *Store) PandoraNetwork() (*types.PandoraNetwork, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.pandoraNetwork == nil {
+		return nil, nil
+	}
+	cpy := *s.pandoraNetwork
+	return &cpy, nil
+}
+
+// PinPandoraNetwork pins the given pandora network unless one is pinned already and returns the pinned one
+func (s *Store) PinPandoraNetwork(network *types.PandoraNetwork) (*types.PandoraNetwork, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.pandoraNetwork == nil {
+		cpy := *network
+		s.pandoraNetwork = &cpy
+	}
+	pinned := *s.pandoraNetwork
+	return &pinned, nil
+}
+
+// VanguardNetwork returns the pinned vanguard network or nil when none is pinned yet.
+func (s *Store) VanguardNetwork() (*types.VanguardNetwork, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.vanguardNetwork == nil {
+		return nil, nil
+	}
+	cpy := *s.vanguardNetwork
+	return &cpy, nil
+}
+
+// PinVanguardNetwork pins the given vanguard network unless one is pinned already and returns the pinned one
+func (s *Store) PinVanguardNetwork(network *types.VanguardNetwork) (*types.VanguardNetwork, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.vanguardNetwork == nil {
+		cpy := *network
+		s.vanguardNetwork = &cpy
+	}
+	pinned := *s.vanguardNetwork
+	return &pinned, nil
+}
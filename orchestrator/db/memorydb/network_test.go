@@ -0,0 +1,42 @@
+package memorydb
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_Network(t *testing.T) {
+	db := setupDB(t)
+	network, err := db.PandoraNetwork()
+	require.NoError(t, err)
+	assert.Equal(t, (*types.PandoraNetwork)(nil), network)
+
+	expected := &types.PandoraNetwork{ChainID: 4004181}
+	network, err = db.PinPandoraNetwork(expected)
+	require.NoError(t, err)
+	assert.DeepEqual(t, expected, network)
+	network, err = db.PandoraNetwork()
+	require.NoError(t, err)
+	assert.DeepEqual(t, expected, network)
+
+	// stored networks can not be mutated by the callers
+	network.ChainID = 1
+	network, err = db.PandoraNetwork()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4004181), network.ChainID)
+
+	// a pinned network is kept, the pin returns it
+	network, err = db.PinPandoraNetwork(&types.PandoraNetwork{ChainID: 1})
+	require.NoError(t, err)
+	assert.DeepEqual(t, expected, network)
+
+	_, err = db.PinVanguardNetwork(&types.VanguardNetwork{GenesisTime: 1624266000})
+	require.NoError(t, err)
+	require.NoError(t, db.ClearDB())
+	vanguardNetwork, err := db.VanguardNetwork()
+	require.NoError(t, err)
+	assert.Equal(t, (*types.VanguardNetwork)(nil), vanguardNetwork)
+}
@@ -0,0 +1,57 @@
+package memorydb
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// SaveLatestFinalizedSlot
+func (s *Store) SaveLatestFinalizedSlot(latestFinalizedSlot uint64) error {
+	return s.updateHeadState(func(headState *types.HeadState) {
+		headState.LatestFinalizedSlot = latestFinalizedSlot
+	})
+}
+
+// LatestLatestFinalizedSlot
+func (s *Store) LatestLatestFinalizedSlot() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.head.LatestFinalizedSlot
+}
+
+// SaveLatestFinalizedEpoch
+func (s *Store) SaveLatestFinalizedEpoch(latestFinalizedEpoch uint64) error {
+	return s.updateHeadState(func(headState *types.HeadState) {
+		headState.LatestFinalizedEpoch = latestFinalizedEpoch
+	})
+}
+
+// LatestLatestFinalizedEpoch
+func (s *Store) LatestLatestFinalizedEpoch() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.head.LatestFinalizedEpoch
+}
+
+// UpdateVerifiedSlotInfo moves the latest verified markers back to the closest verified slot at or below slot.
+func (s *Store) UpdateVerifiedSlotInfo(slot uint64) error {
+	slotNumber, slotInfo, err := s.SeekSlotInfo(slot)
+	if err != nil {
+		return err
+	}
+
+	if slotInfo == nil {
+		log.WithField("slot", slotNumber).Debug("Could not found slot info in verified slot info")
+		return nil
+	}
+
+	log.WithField("slot", slotNumber).WithField("latestVerifiedSlot", slotNumber).
+		Debug("Latest slot till latest finalized slot, updating verified markers")
+
+	// both markers move together so subscribers never observe a half reverted head
+	return s.updateHeadState(func(headState *types.HeadState) {
+		headState.LatestVerifiedSlot = slotNumber
+		headState.LatestVerifiedHeaderHash = slotInfo.PandoraHeaderHash
+	})
+}
@@ -0,0 +1,32 @@
+package memorydb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_UpdateVerifiedSlotInfo(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	for i := 0; i <= 100; i++ {
+		slotInfo := &types.SlotInfo{
+			VanguardBlockHash: common.BytesToHash([]byte{uint8(i)}),
+			PandoraHeaderHash: common.BytesToHash([]byte{uint8(i + 50)}),
+		}
+		require.NoError(t, db.SaveVerifiedSlotInfo(uint64(i), slotInfo))
+	}
+	require.NoError(t, db.SaveLatestVerifiedSlot(ctx, 100))
+	require.NoError(t, db.SaveLatestFinalizedSlot(64))
+
+	// reverting to finalized slot the same way the node does on start up
+	require.NoError(t, db.RemoveRangeVerifiedInfo(db.LatestLatestFinalizedSlot()+1, db.LatestSavedVerifiedSlot()))
+	require.NoError(t, db.UpdateVerifiedSlotInfo(db.LatestLatestFinalizedSlot()))
+
+	assert.Equal(t, uint64(64), db.LatestSavedVerifiedSlot())
+	assert.Equal(t, common.BytesToHash([]byte{uint8(64 + 50)}), db.LatestVerifiedHeaderHash())
+}
@@ -0,0 +1,162 @@
+package memorydb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	EmptyHash      = common.Hash{}
+	errInvalidSlot = errors.New("invalid slot and not found any verified slot info for the given slot")
+)
+
+// SeekSlotInfo walks back from the given slot and returns the first verified slot info it finds.
+func (s *Store) SeekSlotInfo(slot uint64) (uint64, *types.SlotInfo, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for i := slot; i > 0; i-- {
+		if slotInfo, ok := s.verifiedSlotInfos[i]; ok {
+			return i, copySlotInfo(slotInfo), nil
+		}
+	}
+	return 0, nil, nil
+}
+
+// VerifiedSlotInfo
+func (s *Store) VerifiedSlotInfo(slot uint64) (*types.SlotInfo, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return copySlotInfo(s.verifiedSlotInfos[slot]), nil
+}
+
+// VerifiedSlotInfos returns all verified slot infos from fromSlot up to the latest verified slot.
+func (s *Store) VerifiedSlotInfos(fromSlot uint64) (map[uint64]*types.SlotInfo, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	// when requested slot is greater than stored latest verified slot
+	if fromSlot > s.head.LatestVerifiedSlot {
+		return nil, errors.Wrap(errInvalidSlot, fmt.Sprintf("fromSlot: %d", fromSlot))
+	}
+
+	slotInfos := make(map[uint64]*types.SlotInfo)
+	for slot := fromSlot; slot <= s.head.LatestVerifiedSlot; slot++ {
+		if slotInfo, ok := s.verifiedSlotInfos[slot]; ok {
+			slotInfos[slot] = copySlotInfo(slotInfo)
+		}
+	}
+	return slotInfos, nil
+}
+
+// VerifiedSlotByPandoraHash returns the verified slot of the given pandora header hash
+func (s *Store) VerifiedSlotByPandoraHash(hash common.Hash) (uint64, bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	slot, found := s.pandoraHashIndex[hash]
+	return slot, found, nil
+}
+
+// VerifiedSlotByVanguardHash returns the verified slot of the given vanguard block hash
+func (s *Store) VerifiedSlotByVanguardHash(hash common.Hash) (uint64, bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	slot, found := s.vanguardHashIndex[hash]
+	return slot, found, nil
+}
+
+// SaveVerifiedSlotInfo will insert slot information to particular slot.
+// After save operations you must call SaveLatestVerifiedSlot to move the slot height
+func (s *Store) SaveVerifiedSlotInfo(slot uint64, slotInfo *types.SlotInfo) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// the hashes of an overwritten slot info must not point to this slot anymore
+	s.unindexSlotInfo(slot)
+	s.verifiedSlotInfos[slot] = copySlotInfo(slotInfo)
+	s.pandoraHashIndex[slotInfo.PandoraHeaderHash] = slot
+	s.vanguardHashIndex[slotInfo.VanguardBlockHash] = slot
+	return nil
+}
+
+// unindexSlotInfo removes the index entries of the slot info stored at the given slot. Entries which
+// were taken over by another slot are kept. The caller must hold the lock.
+func (s *Store) unindexSlotInfo(slot uint64) {
+	slotInfo, ok := s.verifiedSlotInfos[slot]
+	if !ok {
+		return
+	}
+	if indexed, ok := s.pandoraHashIndex[slotInfo.PandoraHeaderHash]; ok && indexed == slot {
+		delete(s.pandoraHashIndex, slotInfo.PandoraHeaderHash)
+	}
+	if indexed, ok := s.vanguardHashIndex[slotInfo.VanguardBlockHash]; ok && indexed == slot {
+		delete(s.vanguardHashIndex, slotInfo.VanguardBlockHash)
+	}
+}
+
+// SaveLatestVerifiedSlot
+func (s *Store) SaveLatestVerifiedSlot(ctx context.Context, slot uint64) error {
+	return s.updateHeadState(func(headState *types.HeadState) {
+		headState.LatestVerifiedSlot = slot
+	})
+}
+
+// LatestSavedVerifiedSlot
+func (s *Store) LatestSavedVerifiedSlot() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.head.LatestVerifiedSlot
+}
+
+// SaveLatestVerifiedHeaderHash
+func (s *Store) SaveLatestVerifiedHeaderHash(hash common.Hash) error {
+	return s.updateHeadState(func(headState *types.HeadState) {
+		headState.LatestVerifiedHeaderHash = hash
+	})
+}
+
+// LatestVerifiedHeaderHash returns latest verified pandora header hash
+func (s *Store) LatestVerifiedHeaderHash() common.Hash {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.head.LatestVerifiedHeaderHash
+}
+
+// FindVerifiedSlotNumber will try to find matching of verified slot info
+// fromSlot must be higher or equal slot number that is present in db
+func (s *Store) FindVerifiedSlotNumber(info *types.SlotInfo, fromSlot uint64) uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for i := fromSlot; i > 0; i-- {
+		slotInfo, ok := s.verifiedSlotInfos[i]
+		if ok && slotInfo.PandoraHeaderHash == info.PandoraHeaderHash && slotInfo.VanguardBlockHash == info.VanguardBlockHash {
+			return i
+		}
+	}
+	return 0
+}
+
+// RemoveRangeVerifiedInfo method deletes [fromSlot, toSlot]
+func (s *Store) RemoveRangeVerifiedInfo(fromSlot, toSlot uint64) error {
+	log.WithField("fromSlot", fromSlot).WithField("toSlot", toSlot).
+		Debug("Start removing slot infos from verified db!")
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for slot := fromSlot; slot <= toSlot; slot++ {
+		s.unindexSlotInfo(slot)
+		delete(s.verifiedSlotInfos, slot)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package memorydb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_VerifiedSlotInfos(t *testing.T) {
+	db := setupDB(t)
+	slotInfosLen := 64
+	slotInfos := createAndSaveEmptySlotInfos(t, slotInfosLen, db)
+	require.NoError(t, db.SaveLatestVerifiedSlot(context.Background(), uint64(slotInfosLen)-uint64(1)))
+	retrievedSlotInfos, err := db.VerifiedSlotInfos(0)
+	require.NoError(t, err)
+	require.Equal(t, slotInfosLen, len(retrievedSlotInfos))
+	assert.DeepEqual(t, slotInfos[0], retrievedSlotInfos[0])
+
+	_, err = db.VerifiedSlotInfos(uint64(slotInfosLen))
+	require.ErrorContains(t, errInvalidSlot.Error(), err)
+}
+
+func TestStore_FindVerifiedSlotNumber(t *testing.T) {
+	db := setupDB(t)
+	createAndSaveEmptySlotInfos(t, 64, db)
+	customSlotInfo := &types.SlotInfo{
+		VanguardBlockHash: common.HexToHash("0x6f701e4e8b260f38a43cdc0d97cfdc7f0cd33f58ef26bbc6c327ac87d76304d2"),
+		PandoraHeaderHash: common.HexToHash("0x0846da512db0a6888a59aa5f7235b741e36a9dcacc9dad33ee2a228878aefa74"),
+	}
+	require.NoError(t, db.SaveVerifiedSlotInfo(64, customSlotInfo))
+
+	assert.Equal(t, uint64(64), db.FindVerifiedSlotNumber(customSlotInfo, 64))
+	assert.Equal(t, uint64(64), db.FindVerifiedSlotNumber(customSlotInfo, 114))
+	assert.Equal(t, uint64(0), db.FindVerifiedSlotNumber(customSlotInfo, 4))
+}
+
+func TestStore_RemoveRangeVerifiedInfo(t *testing.T) {
+	db := setupDB(t)
+	createAndSaveEmptySlotInfos(t, 64, db)
+	require.NoError(t, db.RemoveRangeVerifiedInfo(32, 63))
+
+	for i := uint64(1); i < 64; i++ {
+		slotInfo, err := db.VerifiedSlotInfo(i)
+		require.NoError(t, err)
+		if i < 32 {
+			assert.NotNil(t, slotInfo)
+			continue
+		}
+		assert.Equal(t, (*types.SlotInfo)(nil), slotInfo)
+	}
+}
+
+func TestStore_VerifiedSlotByHash(t *testing.T) {
+	db := setupDB(t)
+	slotInfo := &types.SlotInfo{
+		VanguardBlockHash: common.HexToHash("0x6f701e4e8b260f38a43cdc0d97cfdc7f0cd33f58ef26bbc6c327ac87d76304d2"),
+		PandoraHeaderHash: common.HexToHash("0x0846da512db0a6888a59aa5f7235b741e36a9dcacc9dad33ee2a228878aefa74"),
+	}
+	require.NoError(t, db.SaveVerifiedSlotInfo(10, slotInfo))
+
+	slot, found, err := db.VerifiedSlotByPandoraHash(slotInfo.PandoraHeaderHash)
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(10), slot)
+	slot, found, err = db.VerifiedSlotByVanguardHash(slotInfo.VanguardBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(10), slot)
+
+	// overwriting the slot drops the previous hashes
+	require.NoError(t, db.SaveVerifiedSlotInfo(10, &types.SlotInfo{}))
+	_, found, err = db.VerifiedSlotByPandoraHash(slotInfo.PandoraHeaderHash)
+	require.NoError(t, err)
+	assert.Equal(t, false, found)
+
+	require.NoError(t, db.SaveVerifiedSlotInfo(11, slotInfo))
+	require.NoError(t, db.RemoveRangeVerifiedInfo(11, 11))
+	_, found, err = db.VerifiedSlotByVanguardHash(slotInfo.VanguardBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, false, found)
+}
+
+func createAndSaveEmptySlotInfos(t *testing.T, slotsLen int, db *Store) (slotInfos []*types.SlotInfo) {
+	slotInfos = make([]*types.SlotInfo, slotsLen)
+
+	for i := 0; i < slotsLen; i++ {
+		slotInfo := new(types.SlotInfo)
+		slotInfo.VanguardBlockHash = eth1Types.EmptyRootHash
+		slotInfo.PandoraHeaderHash = eth1Types.EmptyRootHash
+		slotInfos[i] = slotInfo
+
+		require.NoError(t, db.SaveVerifiedSlotInfo(uint64(i), slotInfo))
+	}
+
+	return
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	latestEpochGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "orchestrator_latest_epoch",
+		Help: "Latest epoch of the stored consensus info",
+	})
+	latestVerifiedSlotGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "orchestrator_latest_verified_slot",
+		Help: "Latest slot which has been verified against both chains",
+	})
+	latestFinalizedSlotGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "orchestrator_latest_finalized_slot",
+		Help: "Latest finalized slot reported by vanguard",
+	})
+	latestFinalizedEpochGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "orchestrator_latest_finalized_epoch",
+		Help: "Latest finalized epoch reported by vanguard",
+	})
+)
+
+// ReportHeadStateMetrics keeps the latest info marker gauges in sync with the head state of
+// the given database. It blocks until the context is cancelled or the database is closed.
+func ReportHeadStateMetrics(ctx context.Context, db HeadStateReader) {
+	headStateCh := make(chan *types.HeadState, 1)
+	sub := db.SubscribeHeadStateEvent(headStateCh)
+	if sub == nil {
+		// database is already closed
+		return
+	}
+	defer sub.Unsubscribe()
+
+	updateHeadStateMetrics(db.HeadState())
+	for {
+		select {
+		case headState := <-headStateCh:
+			updateHeadStateMetrics(headState)
+		case <-sub.Err():
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func updateHeadStateMetrics(headState *types.HeadState) {
+	if headState == nil {
+		return
+	}
+	latestEpochGauge.Set(float64(headState.LatestEpoch))
+	latestVerifiedSlotGauge.Set(float64(headState.LatestVerifiedSlot))
+	latestFinalizedSlotGauge.Set(float64(headState.LatestFinalizedSlot))
+	latestFinalizedEpochGauge.Set(float64(headState.LatestFinalizedEpoch))
+}
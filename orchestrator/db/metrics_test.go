@@ -0,0 +1,54 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestReportHeadStateMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db := NewEphemeralDB(ctx)
+	require.NoError(t, db.SaveLatestEpoch(ctx, 3))
+
+	done := make(chan struct{})
+	go func() {
+		ReportHeadStateMetrics(ctx, db)
+		close(done)
+	}()
+
+	waitForGauge := func(want float64, read func() float64) {
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			if read() == want {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("gauge did not reach %v, got %v", want, read())
+	}
+
+	waitForGauge(3, func() float64 { return promtestutil.ToFloat64(latestEpochGauge) })
+
+	require.NoError(t, db.SaveLatestVerifiedSlot(ctx, 65))
+	require.NoError(t, db.SaveLatestFinalizedSlot(32))
+	require.NoError(t, db.SaveLatestFinalizedEpoch(1))
+	require.NoError(t, db.SaveLatestVerifiedHeaderHash(common.HexToHash("0x01")))
+
+	waitForGauge(65, func() float64 { return promtestutil.ToFloat64(latestVerifiedSlotGauge) })
+	waitForGauge(32, func() float64 { return promtestutil.ToFloat64(latestFinalizedSlotGauge) })
+	waitForGauge(1, func() float64 { return promtestutil.ToFloat64(latestFinalizedEpochGauge) })
+
+	// closing the database ends the reporter
+	require.NoError(t, db.Close())
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("head state reporter did not exit after closing the database")
+	}
+}
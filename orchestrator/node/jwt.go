@@ -0,0 +1,77 @@
+package node
+
+import (
+	"crypto/rand"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// jwtSecretLength is the length in bytes of the HS256 secret
+const jwtSecretLength = 32
+
+var errInvalidJWTSecret = errors.New("invalid JWT secret")
+
+// loadJWTSecret returns the secret used to authenticate http and websocket rpc requests and whether the
+// public namespaces require authentication too. It is the case when the secret file is explicitly configured,
+// otherwise a secret is generated within the datadir and only guards the non-public namespaces.
+func loadJWTSecret(cliCtx *cli.Context) ([]byte, bool, error) {
+	if fileName := cliCtx.String(cmd.AuthRPCJWTSecretFlag.Name); fileName != "" {
+		secret, err := obtainJWTSecret(fileName)
+		return secret, true, err
+	}
+	baseDir := cliCtx.String(cmd.DataDirFlag.Name)
+	if baseDir == "" {
+		log.Warn("No data directory to store the JWT secret, admin namespace is not served over HTTP and WS")
+		return nil, false, nil
+	}
+	secret, err := obtainJWTSecret(filepath.Join(baseDir, cmd.DefaultJWTSecretFileName))
+	return secret, false, err
+}
+
+// obtainJWTSecret loads the hex encoded jwt secret from the given file or generates a new one
+// if the file does not exist.
+func obtainJWTSecret(fileName string) ([]byte, error) {
+	fileName, err := fileutil.ExpandPath(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if fileutil.FileExists(fileName) {
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return nil, err
+		}
+		secret := common.FromHex(strings.TrimSpace(string(data)))
+		if len(secret) != jwtSecretLength {
+			return nil, errors.Wrapf(errInvalidJWTSecret, "expected %d bytes, got %d in %s",
+				jwtSecretLength, len(secret), fileName)
+		}
+		log.WithField("path", fileName).WithField(
+			"crc32", fmt.Sprintf("%#x", crc32.ChecksumIEEE(secret))).Info("Loaded JWT secret file")
+		return secret, nil
+	}
+
+	secret := make([]byte, jwtSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(fileName), params.OrchestratorIoConfig().ReadWriteExecutePermissions); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(fileName, []byte(hexutil.Encode(secret)), params.OrchestratorIoConfig().ReadWritePermissions); err != nil {
+		return nil, err
+	}
+	log.WithField("path", fileName).Info("Generated JWT secret")
+	return secret, nil
+}
@@ -0,0 +1,26 @@
+package node
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func Test_ObtainJWTSecret(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "secrets", "jwtsecret")
+
+	generated, err := obtainJWTSecret(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, jwtSecretLength, len(generated))
+
+	loaded, err := obtainJWTSecret(fileName)
+	require.NoError(t, err)
+	assert.DeepEqual(t, generated, loaded)
+
+	require.NoError(t, ioutil.WriteFile(fileName, []byte("0xdeadbeef"), 0600))
+	_, err = obtainJWTSecret(fileName)
+	assert.ErrorContains(t, errInvalidJWTSecret.Error(), err)
+}
@@ -2,6 +2,7 @@ package node
 
 import (
 	"context"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common/math"
 	ethRpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
@@ -10,10 +11,14 @@ import (
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/admin"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain"
 	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/backoff"
 	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
 	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/prometheus"
+	"github.com/lukso-network/lukso-orchestrator/shared/replay"
 	"github.com/lukso-network/lukso-orchestrator/shared/version"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -43,6 +48,9 @@ type OrchestratorNode struct {
 	// lru caches
 	pandoraInfoCache  *cache.PanHeaderCache
 	vanShardInfoCache *cache.VanShardingInfoCache
+
+	// records the vanguard and pandora streams when enabled
+	recorder *replay.Recorder
 }
 
 // New creates a new node instance, sets up configuration options, and registers
@@ -77,6 +85,10 @@ func New(cliCtx *cli.Context) (*OrchestratorNode, error) {
 		return nil, err
 	}
 
+	if err := orchestrator.registerRecorder(cliCtx); err != nil {
+		return nil, err
+	}
+
 	if err := orchestrator.registerVanguardChainService(cliCtx); err != nil {
 		return nil, err
 	}
@@ -93,11 +105,23 @@ func New(cliCtx *cli.Context) (*OrchestratorNode, error) {
 		return nil, err
 	}
 
+	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
+		if err := orchestrator.registerPrometheusService(cliCtx); err != nil {
+			return nil, err
+		}
+	}
+
 	return orchestrator, nil
 }
 
 // startDB initialize KV db and cache
 func (o *OrchestratorNode) startDB(cliCtx *cli.Context) error {
+	if cliCtx.Bool(cmd.EphemeralFlag.Name) {
+		log.Warn("Running with an ephemeral in-memory database, all data will be lost on shutdown")
+		o.db = db.NewEphemeralDB(o.ctx)
+		return nil
+	}
+
 	baseDir := cliCtx.String(cmd.DataDirFlag.Name)
 	dbPath := filepath.Join(baseDir, kv.OrchestratorNodeDbDirName)
 	clearDB := cliCtx.Bool(cmd.ClearDB.Name)
@@ -143,25 +167,83 @@ func (o *OrchestratorNode) startDB(cliCtx *cli.Context) error {
 	return nil
 }
 
+// registerPrometheusService serves metrics, health and readiness of the registered services
+func (o *OrchestratorNode) registerPrometheusService(cliCtx *cli.Context) error {
+	var consensusSvc *consensus.Service
+	if err := o.services.FetchService(&consensusSvc); err != nil {
+		return err
+	}
+
+	address := fmt.Sprintf(
+		"%s:%d",
+		cliCtx.String(cmd.MonitoringHostFlag.Name),
+		cliCtx.Int(cmd.MonitoringPortFlag.Name),
+	)
+	svc := prometheus.NewService(address, o.services, prometheus.Handler{
+		Path:    "/readyz",
+		Handler: readyzHandler(o.services, consensusSvc),
+	})
+	// keeps latest info marker gauges in sync with the database head state
+	go db.ReportHeadStateMetrics(o.ctx, o.db)
+
+	log.WithField("address", address).Info("Registered prometheus service")
+	return o.services.RegisterService(svc)
+}
+
+// backoffConfig returns the backoff between the reconnection attempts with the chain nodes.
+func backoffConfig(cliCtx *cli.Context) *backoff.Config {
+	cfg := backoff.DefaultConfig()
+	cfg.MaxInterval = cliCtx.Duration(cmd.ReconnectMaxIntervalFlag.Name)
+	cfg.MaxAttempts = cliCtx.Int(cmd.ReconnectMaxAttemptsFlag.Name)
+	return cfg
+}
+
+// registerRecorder records the vanguard and pandora streams into the configured file. The recorder is
+// registered before the chain services, so it is stopped after them.
+func (o *OrchestratorNode) registerRecorder(cliCtx *cli.Context) error {
+	path := cliCtx.String(cmd.RecordStreamsFlag.Name)
+	if path == "" {
+		return nil
+	}
+	recorder, err := replay.NewRecorder(path)
+	if err != nil {
+		return err
+	}
+	o.recorder = recorder
+	log.WithField("path", path).Info("Registered stream recorder")
+	return o.services.RegisterService(recorder)
+}
+
 // registerVanguardChainService
 func (o *OrchestratorNode) registerVanguardChainService(cliCtx *cli.Context) error {
-	vanguardGRPCUrl := cliCtx.String(cmd.VanguardGRPCEndpoint.Name)
+	vanguardGRPCUrls := cmd.SplitAndTrim(cliCtx.String(cmd.VanguardGRPCEndpoint.Name))
 	svc, err := vanguardchain.NewService(
 		o.ctx,
-		vanguardGRPCUrl,
+		vanguardGRPCUrls,
+		&vanguardchain.DialConfig{
+			TLSCACert:          cliCtx.String(cmd.VanguardTLSCACertFlag.Name),
+			TLSClientCert:      cliCtx.String(cmd.VanguardTLSClientCertFlag.Name),
+			TLSClientKey:       cliCtx.String(cmd.VanguardTLSClientKeyFlag.Name),
+			BearerToken:        cliCtx.String(cmd.VanguardBearerTokenFlag.Name),
+			MaxCallRecvMsgSize: cliCtx.Int(cmd.VanguardGRPCMaxMsgSizeFlag.Name),
+			GRPCRetries:        cliCtx.Uint(cmd.VanguardGRPCRetriesFlag.Name),
+			GRPCRetryDelay:     cliCtx.Duration(cmd.VanguardGRPCRetryDelayFlag.Name),
+		},
 		o.db,
 		o.vanShardInfoCache,
+		backoffConfig(cliCtx),
 	)
 	if err != nil {
-		return nil
+		return err
 	}
-	log.WithField("vanguardGRPCUrl", vanguardGRPCUrl).Info("Registered vanguard chain service")
+	svc.SetRecorder(o.recorder)
+	log.WithField("vanguardGRPCUrls", vanguardGRPCUrls).Info("Registered vanguard chain service")
 	return o.services.RegisterService(svc)
 }
 
 // registerPandoraChainService
 func (o *OrchestratorNode) registerPandoraChainService(cliCtx *cli.Context) error {
-	pandoraRPCUrl := cliCtx.String(cmd.PandoraRPCEndpoint.Name)
+	pandoraRPCUrls := cmd.SplitAndTrim(cliCtx.String(cmd.PandoraRPCEndpoint.Name))
 	dialRPCClient := func(endpoint string) (*ethRpc.Client, error) {
 		rpcClient, err := ethRpc.Dial(endpoint)
 		if err != nil {
@@ -170,11 +252,13 @@ func (o *OrchestratorNode) registerPandoraChainService(cliCtx *cli.Context) erro
 		return rpcClient, nil
 	}
 	namespace := "eth"
-	svc, err := pandorachain.NewService(o.ctx, pandoraRPCUrl, namespace, o.db, o.pandoraInfoCache, dialRPCClient)
+	quorum := cliCtx.Int(cmd.PandoraQuorumFlag.Name)
+	svc, err := pandorachain.NewService(o.ctx, pandoraRPCUrls, namespace, o.db, o.pandoraInfoCache, dialRPCClient, quorum, backoffConfig(cliCtx))
 	if err != nil {
-		return nil
+		return err
 	}
-	log.WithField("pandoraHttpUrl", pandoraRPCUrl).Info("Registered pandora chain service")
+	svc.SetRecorder(o.recorder)
+	log.WithField("pandoraHttpUrls", pandoraRPCUrls).WithField("quorum", quorum).Info("Registered pandora chain service")
 	return o.services.RegisterService(svc)
 }
 
@@ -215,6 +299,11 @@ func (o *OrchestratorNode) registerRPCService(cliCtx *cli.Context) error {
 		return err
 	}
 
+	var pandoraService *pandorachain.Service
+	if err := o.services.FetchService(&pandoraService); err != nil {
+		return err
+	}
+
 	var ipcapiURL string
 	if cliCtx.String(cmd.IPCPathFlag.Name) != "" {
 		ipcFilePath := cliCtx.String(cmd.IPCPathFlag.Name)
@@ -230,11 +319,21 @@ func (o *OrchestratorNode) registerRPCService(cliCtx *cli.Context) error {
 	wsEnable := cliCtx.Bool(cmd.WSEnabledFlag.Name)
 	wsListenerAddr := cliCtx.String(cmd.WSListenAddrFlag.Name)
 	wsPort := cliCtx.Int(cmd.WSPortFlag.Name)
+	httpTimeouts := ethRpc.HTTPTimeouts{
+		ReadTimeout:  cliCtx.Duration(cmd.HTTPReadTimeoutFlag.Name),
+		WriteTimeout: cliCtx.Duration(cmd.HTTPWriteTimeoutFlag.Name),
+		IdleTimeout:  cliCtx.Duration(cmd.HTTPIdleTimeoutFlag.Name),
+	}
 
 	log.WithField("httpEnable", httpEnable).WithField("httpListenAddr", httpListenAddr).WithField(
 		"httpPort", httpPort).WithField("wsEnable", wsEnable).WithField(
 		"wsListenerAddr", wsListenerAddr).WithField("wsPort", wsPort).Debug("rpc server configuration")
 
+	jwtSecret, authPublicAPIs, err := loadJWTSecret(cliCtx)
+	if err != nil {
+		return err
+	}
+
 	svc, err := rpc.NewService(o.ctx, &rpc.Config{
 		ConsensusInfoFeed: consensusInfoFeed,
 		Db:                o.db,
@@ -245,13 +344,32 @@ func (o *OrchestratorNode) registerRPCService(cliCtx *cli.Context) error {
 		WSEnable:          wsEnable,
 		WSHost:            wsListenerAddr,
 		WSPort:            wsPort,
+		HTTPCors:          cmd.SplitAndTrim(cliCtx.String(cmd.HTTPCORSDomainFlag.Name)),
+		HTTPVirtualHosts:  cmd.SplitAndTrim(cliCtx.String(cmd.HTTPVirtualHostsFlag.Name)),
+		HTTPModules:       cmd.SplitAndTrim(cliCtx.String(cmd.HTTPApiFlag.Name)),
+		HTTPTimeouts:      httpTimeouts,
+		HTTPPathPrefix:    cliCtx.String(cmd.HTTPPathPrefixFlag.Name),
+		WSOrigins:         cmd.SplitAndTrim(cliCtx.String(cmd.WSAllowedOriginsFlag.Name)),
+		WSModules:         cmd.SplitAndTrim(cliCtx.String(cmd.WSApiFlag.Name)),
+		WSPathPrefix:      cliCtx.String(cmd.WSPathPrefixFlag.Name),
+		JWTSecret:         jwtSecret,
+		AuthPublicAPIs:    authPublicAPIs,
+		TLSCertFile:       cliCtx.String(cmd.RPCTLSCertFlag.Name),
+		TLSKeyFile:        cliCtx.String(cmd.RPCTLSKeyFlag.Name),
+		TLSClientCAFile:   cliCtx.String(cmd.RPCTLSClientCAFlag.Name),
 
 		VanguardPendingShardingCache: o.vanShardInfoCache,
 		PandoraPendingHeaderCache:    o.pandoraInfoCache,
 		VerifiedSlotInfoFeed:         verifiedSlotInfoFeed,
+		SyncStatusReader:             verifiedSlotInfoFeed,
+		VanguardController:           consensusInfoFeed,
+		PandoraController:            pandoraService,
+		SlotReverter:                 verifiedSlotInfoFeed,
+		ReconnectEventFeeds:          []admin.ReconnectEventFeed{consensusInfoFeed, pandoraService},
+		HeaderDivergenceFeed:         pandoraService,
 	})
 	if err != nil {
-		return nil
+		return err
 	}
 
 	log.Info("Registered RPC service")
@@ -2,7 +2,9 @@ package node
 
 import (
 	"flag"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
 	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/prometheus"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/urfave/cli/v2"
@@ -46,3 +48,40 @@ func Test_ClearDB(t *testing.T) {
 	require.LogsContain(t, hook, "Removing database")
 	require.NoError(t, os.RemoveAll(tmp))
 }
+
+// Test_Node_Ephemeral tests that the node runs with an in-memory database and writes nothing to the data directory
+func Test_Node_Ephemeral(t *testing.T) {
+	hook := logTest.NewGlobal()
+	tmp := filepath.Join(t.TempDir(), "datadirtest")
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String("datadir", tmp, "node data directory")
+	set.Bool(cmd.EphemeralFlag.Name, true, "in-memory db")
+
+	context := cli.NewContext(&app, set, nil)
+	node, err := New(context)
+	require.NoError(t, err)
+	require.Equal(t, "", node.db.DatabasePath())
+
+	node.Close()
+	require.LogsContain(t, hook, "Running with an ephemeral in-memory database")
+	_, err = os.Stat(filepath.Join(tmp, kv.OrchestratorNodeDbDirName))
+	require.Equal(t, true, os.IsNotExist(err))
+}
+
+// Test_Node_DisableMonitoring tests that the prometheus service is not registered when monitoring is disabled
+func Test_Node_DisableMonitoring(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.Bool(cmd.EphemeralFlag.Name, true, "in-memory db")
+	set.Bool(cmd.DisableMonitoringFlag.Name, true, "disable monitoring")
+
+	context := cli.NewContext(&app, set, nil)
+	node, err := New(context)
+	require.NoError(t, err)
+
+	var prometheusService *prometheus.Service
+	require.ErrorContains(t, "unknown service", node.services.FetchService(&prometheusService))
+	node.Close()
+}
@@ -0,0 +1,50 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/consensus/iface"
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// readiness is the response body of the /readyz endpoint
+type readiness struct {
+	Ready         bool              `json:"ready"`
+	SyncStatus    *types.SyncStatus `json:"syncStatus"`
+	ServiceErrors map[string]string `json:"serviceErrors,omitempty"`
+}
+
+// readyzHandler reports the node as ready only when all services are healthy, both chains are
+// connected and the orchestrator is not catching up with vanguard or handling a reorg.
+func readyzHandler(registry *shared.ServiceRegistry, syncStatusReader iface.SyncStatusReader) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		syncStatus := syncStatusReader.SyncStatus()
+		response := &readiness{
+			Ready:      syncStatus.Ready(),
+			SyncStatus: syncStatus,
+		}
+
+		for serviceType, err := range registry.Statuses() {
+			if err == nil {
+				continue
+			}
+			if response.ServiceErrors == nil {
+				response.ServiceErrors = make(map[string]string)
+			}
+			response.ServiceErrors[serviceType.String()] = err.Error()
+			response.Ready = false
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if response.Ready {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.WithError(err).Error("Failed to write readiness response")
+		}
+	}
+}
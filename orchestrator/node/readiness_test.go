@@ -0,0 +1,77 @@
+package node
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+type mockSyncStatusReader struct {
+	syncStatus *types.SyncStatus
+}
+
+func (m *mockSyncStatusReader) SyncStatus() *types.SyncStatus {
+	return m.syncStatus
+}
+
+type mockService struct {
+	status error
+}
+
+func (m *mockService) Start() {}
+
+func (m *mockService) Stop() error {
+	return nil
+}
+
+func (m *mockService) Status() error {
+	return m.status
+}
+
+func TestReadyzHandler(t *testing.T) {
+	registry := shared.NewServiceRegistry()
+	svc := &mockService{}
+	require.NoError(t, registry.RegisterService(svc))
+	reader := &mockSyncStatusReader{syncStatus: &types.SyncStatus{
+		VanguardConnected: true,
+		PandoraConnected:  true,
+	}}
+	handler := http.HandlerFunc(readyzHandler(registry, reader))
+
+	serve := func() *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", "/readyz", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := serve()
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, true, strings.Contains(rr.Body.String(), `"ready":true`))
+
+	// catching up with vanguard
+	reader.syncStatus.Syncing = true
+	rr = serve()
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	reader.syncStatus.Syncing = false
+
+	// pandora subscription is down
+	reader.syncStatus.PandoraConnected = false
+	rr = serve()
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	reader.syncStatus.PandoraConnected = true
+
+	// unhealthy service
+	svc.status = errors.New("something went wrong")
+	rr = serve()
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(t, true, strings.Contains(rr.Body.String(), "something went wrong"))
+}
@@ -0,0 +1,68 @@
+package pandorachain
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	errNotConnected   = errors.New("not connected to any pandora node")
+	errHeaderNotFound = errors.New("pandora header not found")
+)
+
+// HeaderByHash requests the header with the given hash from the connected pandora node.
+func (s *Service) HeaderByHash(ctx context.Context, hash common.Hash) (*types.PandoraHeaderInfo, error) {
+	return s.fetchHeader(ctx, "_getBlockByHash", hash)
+}
+
+// HeaderByNumber requests the header with the given block number from the connected pandora node.
+func (s *Service) HeaderByNumber(ctx context.Context, number uint64) (*types.PandoraHeaderInfo, error) {
+	return s.fetchHeader(ctx, "_getBlockByNumber", hexutil.EncodeUint64(number))
+}
+
+// fetchHeader requests a header with the given method, without the transactions of the block.
+func (s *Service) fetchHeader(ctx context.Context, method string, arg interface{}) (*types.PandoraHeaderInfo, error) {
+	client := s.activeClient()
+	if client == nil {
+		return nil, errNotConnected
+	}
+	var header *eth1Types.Header
+	if err := client.CallContext(ctx, &header, s.namespace+method, arg, false); err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errors.Wrapf(errHeaderNotFound, "%v", arg)
+	}
+	return newHeaderInfo(header)
+}
+
+// activeClient returns the client of the active endpoint or, in quorum mode, of any connected member.
+func (s *Service) activeClient() *rpc.Client {
+	for _, member := range s.members {
+		if client := member.activeClient(); client != nil {
+			return client
+		}
+	}
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	return s.rpcClient
+}
+
+// newHeaderInfo reads the slot of a pandora header from its extra data.
+func newHeaderInfo(header *eth1Types.Header) (*types.PandoraHeaderInfo, error) {
+	var panExtraDataWithSig types.PanExtraDataWithBLSSig
+	if err := rlp.DecodeBytes(header.Extra, &panExtraDataWithSig); err != nil {
+		return nil, err
+	}
+	return &types.PandoraHeaderInfo{
+		Header: header,
+		Slot:   panExtraDataWithSig.Slot,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package pandorachain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/pkg/errors"
+)
+
+func TestService_FetchHeader(t *testing.T) {
+	ctx := context.Background()
+	inProcServer, panService := SetupInProcServer(t)
+	defer inProcServer.Stop()
+	panSvc := SetupPandoraSvc(ctx, t, DialInProcClient(inProcServer))
+
+	_, err := panSvc.HeaderByNumber(ctx, 5)
+	assert.Equal(t, true, errors.Is(err, errNotConnected))
+
+	require.NoError(t, panSvc.dialClient())
+	header := testutil.NewEth1Header(5)
+	panService.addPendingHeader(header)
+
+	headerInfo, err := panSvc.HeaderByHash(ctx, header.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, header.Hash(), headerInfo.Header.Hash())
+	assert.Equal(t, uint64(5), headerInfo.Slot)
+
+	headerInfo, err = panSvc.HeaderByNumber(ctx, header.Number.Uint64())
+	require.NoError(t, err)
+	assert.Equal(t, header.Hash(), headerInfo.Header.Hash())
+
+	_, err = panSvc.HeaderByHash(ctx, common.HexToHash("0x01"))
+	assert.Equal(t, true, errors.Is(err, errHeaderNotFound))
+}
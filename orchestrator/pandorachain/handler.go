@@ -3,28 +3,24 @@ package pandorachain
 import (
 	"context"
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/rlp"
-	"github.com/lukso-network/lukso-orchestrator/shared/types"
 )
 
 // OnNewPendingHeader :
-//	- cache and store header and header hash with status
-//  - send to consensus service for checking header with vanguard header for confirmation
+//   - cache and store header and header hash with status
+//   - send to consensus service for checking header with vanguard header for confirmation
 func (s *Service) OnNewPendingHeader(ctx context.Context, header *eth1Types.Header) error {
-	var panExtraDataWithSig types.PanExtraDataWithBLSSig
-	if err := rlp.DecodeBytes(header.Extra, &panExtraDataWithSig); err != nil {
+	s.recorder.RecordPandoraHeader(header)
+	headerInfo, err := newHeaderInfo(header)
+	if err != nil {
 		log.WithError(err).Error("Failed to decode extra data fields")
 		return err
 	}
 
-	log.WithField("slot", panExtraDataWithSig.Slot).
+	log.WithField("slot", headerInfo.Slot).
 		WithField("blockNumber", header.Number.Uint64()).
 		WithField("headerHash", header.Hash()).
 		Info("New pandora header info has arrived")
 
-	s.pandoraHeaderInfoFeed.Send(&types.PandoraHeaderInfo{
-		Header: header,
-		Slot:   panExtraDataWithSig.Slot,
-	})
+	s.pandoraHeaderInfoFeed.Send(headerInfo)
 	return nil
 }
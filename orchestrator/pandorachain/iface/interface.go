@@ -1,6 +1,9 @@
 package iface
 
 import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 )
@@ -9,4 +12,7 @@ type PandoraService interface {
 	SubscribeHeaderInfoEvent(chan<- *types.PandoraHeaderInfo) event.Subscription
 	StopPandoraSubscription()
 	ResumePandoraSubscription() error
+	IsConnected() bool
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.PandoraHeaderInfo, error)
+	HeaderByNumber(ctx context.Context, number uint64) (*types.PandoraHeaderInfo, error)
 }
@@ -0,0 +1,25 @@
+package pandorachain
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	pandoraConnected = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "orchestrator_pandora_connected",
+		Help: "Boolean indicating whether the orchestrator is connected to the pandora node",
+	})
+	pandoraReconnects = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "orchestrator_pandora_reconnects_total",
+		Help: "Number of times the orchestrator re-established its subscriptions with the pandora node",
+	})
+	pandoraFailovers = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "orchestrator_pandora_failovers_total",
+		Help: "Number of times the orchestrator failed over to another pandora endpoint",
+	})
+	pandoraHeaderDivergences = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "orchestrator_pandora_header_divergences_total",
+		Help: "Number of slots for which the pandora nodes reported different headers",
+	})
+)
@@ -0,0 +1,67 @@
+package pandorachain
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// time to wait for the network of the pandora node.
+var handshakeTimeout = 10 * time.Second
+
+var (
+	errNetworkMismatch = errors.New("pandora node belongs to another network than the orchestrator database")
+	errMissingGenesis  = errors.New("pandora node did not return its genesis header")
+)
+
+// verifyNetwork checks the chain id and the genesis hash of the connected pandora node against the ones
+// pinned in the database. The network of the first connected node is pinned. A node of another network
+// is refused, the orchestrator must not mix the data of several networks.
+func (s *Service) verifyNetwork(client *rpc.Client, endpoint string) error {
+	network, err := s.fetchNetwork(client)
+	if err != nil {
+		return err
+	}
+
+	pinned, err := s.db.PinPandoraNetwork(network)
+	if err != nil {
+		return err
+	}
+	if *pinned != *network {
+		return errors.Wrapf(errNetworkMismatch,
+			"node %s has chain id %d and genesis %s, database was built for chain id %d and genesis %s "+
+				"(remove the database with --clear-db to switch networks)",
+			endpoint, network.ChainID, network.GenesisHash, pinned.ChainID, pinned.GenesisHash)
+	}
+	log.WithField("chainId", network.ChainID).WithField("genesisHash", network.GenesisHash).
+		Debug("Verified pandora network")
+	return nil
+}
+
+// fetchNetwork requests the chain id and the genesis header of the connected pandora node.
+func (s *Service) fetchNetwork(client *rpc.Client) (*types.PandoraNetwork, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, handshakeTimeout)
+	defer cancel()
+
+	var chainID hexutil.Big
+	if err := client.CallContext(ctx, &chainID, s.namespace+"_chainId"); err != nil {
+		return nil, errors.Wrap(err, "could not get pandora chain id")
+	}
+	var genesis *eth1Types.Header
+	if err := client.CallContext(ctx, &genesis, s.namespace+"_getBlockByNumber", "0x0", false); err != nil {
+		return nil, errors.Wrap(err, "could not get pandora genesis header")
+	}
+	if genesis == nil {
+		return nil, errMissingGenesis
+	}
+	s.recorder.RecordPandoraNetwork(chainID.ToInt().Uint64(), genesis)
+	return &types.PandoraNetwork{
+		ChainID:     chainID.ToInt().Uint64(),
+		GenesisHash: genesis.Hash(),
+	}, nil
+}
@@ -0,0 +1,80 @@
+package pandorachain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/backoff"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+func TestService_VerifyNetwork(t *testing.T) {
+	ctx := context.Background()
+	inProcServer, panService := SetupInProcServer(t)
+	defer inProcServer.Stop()
+	panSvc := SetupPandoraSvc(ctx, t, DialInProcClient(inProcServer))
+	client := rpc.DialInProc(inProcServer)
+	defer client.Close()
+
+	// the network of the first node is pinned
+	require.NoError(t, panSvc.verifyNetwork(client, "ws://a"))
+	pinned, err := panSvc.db.PandoraNetwork()
+	require.NoError(t, err)
+	require.NotNil(t, pinned)
+	assert.Equal(t, uint64(1), pinned.ChainID)
+	assert.Equal(t, panService.genesis.Hash(), pinned.GenesisHash)
+	require.NoError(t, panSvc.verifyNetwork(client, "ws://a"))
+
+	// nodes of another network are refused
+	panService.chainID = 2
+	assert.Equal(t, true, errors.Is(panSvc.verifyNetwork(client, "ws://b"), errNetworkMismatch))
+
+	panService.chainID = 1
+	panService.genesis.GasLimit++
+	assert.Equal(t, true, errors.Is(panSvc.verifyNetwork(client, "ws://c"), errNetworkMismatch))
+}
+
+// TestService_NetworkMismatchFailover checks that an endpoint of another network is excluded and failed over
+// from, and that the service only gives up once no endpoint of the pinned network is left.
+func TestService_NetworkMismatchFailover(t *testing.T) {
+	ctx := context.Background()
+	serverA, panServiceA := SetupInProcServer(t)
+	defer serverA.Stop()
+	serverB, panServiceB := SetupInProcServer(t)
+	defer serverB.Stop()
+	panServiceA.chainID = 2
+	dialRPCFn := dialInProcServers(map[string]*rpc.Server{"ws://a": serverA, "ws://b": serverB})
+	backoffCfg := &backoff.Config{InitialInterval: time.Millisecond, Multiplier: 1, MaxAttempts: 10}
+
+	database := testDB.SetupDB(t)
+	_, err := database.PinPandoraNetwork(&types.PandoraNetwork{
+		ChainID:     1,
+		GenesisHash: panServiceB.genesis.Hash(),
+	})
+	require.NoError(t, err)
+	panSvc, err := NewService(ctx, []string{"ws://a", "ws://b"}, "eth", database, cache.NewPanHeaderCache(),
+		dialRPCFn, 0, backoffCfg)
+	require.NoError(t, err)
+	defer panSvc.Stop()
+	require.NoError(t, panSvc.waitForConnection())
+	assert.Equal(t, "ws://b", panSvc.endpoint)
+
+	// the excluded endpoint is skipped by the next failover
+	panSvc.failover()
+	assert.Equal(t, "ws://b", panSvc.endpoint)
+
+	// without any endpoint of the pinned network the service gives up without retrying
+	panServiceB.chainID = 2
+	panSvc.closeClients()
+	panSvc.rpcClient = nil
+	err = panSvc.waitForConnection()
+	assert.Equal(t, true, errors.Is(err, errNetworkMismatch))
+	assert.Equal(t, 0, panSvc.backoff.Attempts())
+}
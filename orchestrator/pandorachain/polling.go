@@ -0,0 +1,121 @@
+package pandorachain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+var (
+	// time to wait between two polls of the pending headers over http.
+	headerPollPeriod = time.Second
+	// time to wait for the pending headers of a single poll.
+	headerPollTimeout = 10 * time.Second
+)
+
+// isHTTPEndpoint returns true when the endpoint is served over http, which does not support subscriptions.
+func isHTTPEndpoint(endpoint string) bool {
+	endpoint = strings.ToLower(endpoint)
+	return strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://")
+}
+
+// PollPendingHeaders polls the pandora client for the pending headers following the header of the given
+// filter. Every poll starts after the last received header, so the headers are dispatched the same way
+// as through SubscribePendingHeaders.
+func (s *Service) PollPendingHeaders(
+	ctx context.Context,
+	crit *types.PandoraPendingHeaderFilter,
+	namespace string,
+	client *rpc.Client,
+) (event.Subscription, error) {
+	method := namespace + "_getPendingBlockHeaders"
+	// the first poll is done right away so an unsupported method is reported like a failing subscription
+	var headers []*eth1Types.Header
+	if err := pollPendingHeaders(ctx, client, &headers, method, crit); err != nil {
+		return nil, err
+	}
+	log.WithField("filterCriteria", crit).Info("polling pandora chain for pending block headers")
+
+	sub := event.NewSubscription(func(quit <-chan struct{}) error {
+		// a running poll is cancelled as soon as the subscription quits
+		pollCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			select {
+			case <-quit:
+				cancel()
+			case <-pollCtx.Done():
+			}
+		}()
+
+		fromBlockHash := crit.FromBlockHash
+		ticker := time.NewTicker(headerPollPeriod)
+		defer ticker.Stop()
+
+		for {
+			for _, header := range headers {
+				// dispatch newPendingHeader to handler
+				if err := s.OnNewPendingHeader(ctx, header); err != nil {
+					log.WithError(err).Error("Failed to process the pending pandora header")
+					return errPandoraHeaderProcessing
+				}
+				fromBlockHash = header.Hash()
+			}
+
+			select {
+			case <-ticker.C:
+			case <-quit:
+				return nil
+			case <-s.conDisconnect:
+				log.Info("Received re-org event, exiting pandora pending block polling!")
+				return nil
+			case <-ctx.Done():
+				log.Info("Received cancelled context, stopping pending pandora headers polling")
+				return nil
+			}
+
+			headers = nil
+			filter := &types.PandoraPendingHeaderFilter{FromBlockHash: fromBlockHash}
+			if err := pollPendingHeaders(pollCtx, client, &headers, method, filter); err != nil {
+				if pollCtx.Err() != nil {
+					return nil
+				}
+				return err
+			}
+		}
+	})
+
+	go func() {
+		err := <-sub.Err()
+		if err == nil {
+			return
+		}
+		log.WithError(err).Debug("Got polling error")
+		if s.isReplacedSubscription(sub) {
+			log.Debug("Pandora polling has been replaced, exiting pending block polling")
+			return
+		}
+		s.conInfoSubErrCh <- err
+	}()
+
+	return sub, nil
+}
+
+// pollPendingHeaders requests the pending headers following the header of the filter, giving up after
+// headerPollTimeout.
+func pollPendingHeaders(
+	ctx context.Context,
+	client *rpc.Client,
+	headers *[]*eth1Types.Header,
+	method string,
+	crit *types.PandoraPendingHeaderFilter,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, headerPollTimeout)
+	defer cancel()
+	return client.CallContext(ctx, headers, method, crit)
+}
@@ -0,0 +1,126 @@
+package pandorachain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestIsHTTPEndpoint(t *testing.T) {
+	assert.Equal(t, true, isHTTPEndpoint("http://127.0.0.1:8545"))
+	assert.Equal(t, true, isHTTPEndpoint("HTTPS://pandora.example.com"))
+	assert.Equal(t, false, isHTTPEndpoint("ws://127.0.0.1:8546"))
+	assert.Equal(t, false, isHTTPEndpoint("/tmp/pandora.ipc"))
+}
+
+// Test_PandoraSvc_PollPendingHeaders checks that an http endpoint is polled for the pending headers
+func Test_PandoraSvc_PollPendingHeaders(t *testing.T) {
+	ctx := context.Background()
+	defer func(period time.Duration) { headerPollPeriod = period }(headerPollPeriod)
+	headerPollPeriod = 100 * time.Millisecond
+
+	inProcServer, panService := SetupInProcServer(t)
+	defer inProcServer.Stop()
+	dialRPCFn := dialInProcServers(map[string]*rpc.Server{"http://127.0.0.1:8545": inProcServer})
+
+	panSvc, err := NewService(ctx, []string{"http://127.0.0.1:8545"}, "eth", testDB.SetupDB(t),
+		cache.NewPanHeaderCache(), dialRPCFn, 0, nil)
+	require.NoError(t, err)
+	headerInfoCh := make(chan *types.PandoraHeaderInfo, 3)
+	headerSub := panSvc.SubscribeHeaderInfoEvent(headerInfoCh)
+	defer headerSub.Unsubscribe()
+
+	panService.addPendingHeader(testutil.NewEth1Header(1))
+	panSvc.Start()
+	defer panSvc.Stop()
+
+	time.Sleep(500 * time.Millisecond)
+	panService.addPendingHeader(testutil.NewEth1Header(2))
+	panService.addPendingHeader(testutil.NewEth1Header(3))
+
+	for slot := uint64(1); slot <= 3; slot++ {
+		select {
+		case headerInfo := <-headerInfoCh:
+			assert.Equal(t, slot, headerInfo.Slot)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("header of slot %d not polled", slot)
+		}
+	}
+	select {
+	case headerInfo := <-headerInfoCh:
+		t.Fatalf("header of slot %d polled twice", headerInfo.Slot)
+	case <-time.After(300 * time.Millisecond):
+	}
+	assert.Equal(t, true, panSvc.IsConnected())
+}
+
+// Test_PandoraSvc_PollTimeout checks that a hanging poll times out and the connection is re-established
+func Test_PandoraSvc_PollTimeout(t *testing.T) {
+	ctx := context.Background()
+	defer func(period, timeout time.Duration) {
+		headerPollPeriod, headerPollTimeout = period, timeout
+	}(headerPollPeriod, headerPollTimeout)
+	headerPollPeriod = 50 * time.Millisecond
+
+	inProcServer, panService := SetupInProcServer(t)
+	defer inProcServer.Stop()
+	dialRPCFn := dialInProcServers(map[string]*rpc.Server{"http://127.0.0.1:8545": inProcServer})
+	panSvc, err := NewService(ctx, []string{"http://127.0.0.1:8545"}, "eth", testDB.SetupDB(t),
+		cache.NewPanHeaderCache(), dialRPCFn, 0, nil)
+	require.NoError(t, err)
+	panSvc.Start()
+	defer panSvc.Stop()
+	time.Sleep(200 * time.Millisecond)
+	require.Equal(t, true, panSvc.IsConnected())
+
+	headerPollTimeout = 100 * time.Millisecond
+	reconnects := promtestutil.ToFloat64(pandoraReconnects)
+	unblock := panService.blockPolls()
+	defer close(unblock)
+	deadline := time.Now().Add(2 * time.Second)
+	for promtestutil.ToFloat64(pandoraReconnects) == reconnects {
+		if time.Now().After(deadline) {
+			t.Fatal("hanging poll did not time out")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// Test_PandoraSvc_PollUnsubscribe checks that unsubscribing cancels a running poll
+func Test_PandoraSvc_PollUnsubscribe(t *testing.T) {
+	ctx := context.Background()
+	defer func(period time.Duration) { headerPollPeriod = period }(headerPollPeriod)
+	headerPollPeriod = 10 * time.Millisecond
+
+	inProcServer, panService := SetupInProcServer(t)
+	defer inProcServer.Stop()
+	panSvc := SetupPandoraSvc(ctx, t, DialInProcClient(inProcServer))
+	client := rpc.DialInProc(inProcServer)
+	defer client.Close()
+
+	sub, err := panSvc.PollPendingHeaders(ctx, &types.PandoraPendingHeaderFilter{}, "eth", client)
+	require.NoError(t, err)
+	unblock := panService.blockPolls()
+	defer close(unblock)
+	time.Sleep(100 * time.Millisecond)
+
+	unsubscribed := make(chan struct{})
+	go func() {
+		sub.Unsubscribe()
+		close(unsubscribed)
+	}()
+	select {
+	case <-unsubscribed:
+	case <-time.After(time.Second):
+		t.Fatal("running poll not cancelled by unsubscribe")
+	}
+}
@@ -0,0 +1,151 @@
+package pandorachain
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// number of slots below the highest voted slot for which the votes are kept.
+const quorumSlotWindow = 64
+
+// slotVotes holds the headers reported by the pandora nodes for a slot
+type slotVotes struct {
+	headers   map[common.Hash]*types.PandoraHeaderInfo
+	voters    map[common.Hash]map[string]bool
+	forwarded bool
+}
+
+// headerQuorum counts the pandora nodes reporting the same header for a slot.
+type headerQuorum struct {
+	quorum      int
+	lock        sync.Mutex
+	slots       map[uint64]*slotVotes
+	highestSlot uint64
+}
+
+func newHeaderQuorum(quorum int) *headerQuorum {
+	return &headerQuorum{
+		quorum: quorum,
+		slots:  make(map[uint64]*slotVotes),
+	}
+}
+
+// vote records the header reported by the given endpoint. It returns the header once it reached the quorum
+// for the first time, and a divergence when the endpoint reported a header other endpoints did not report.
+func (q *headerQuorum) vote(
+	endpoint string,
+	headerInfo *types.PandoraHeaderInfo,
+) (*types.PandoraHeaderInfo, *types.PandoraHeaderDivergence) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	slot := headerInfo.Slot
+	if q.highestSlot > quorumSlotWindow && slot < q.highestSlot-quorumSlotWindow {
+		return nil, nil
+	}
+	votes, ok := q.slots[slot]
+	if !ok {
+		votes = &slotVotes{
+			headers: make(map[common.Hash]*types.PandoraHeaderInfo),
+			voters:  make(map[common.Hash]map[string]bool),
+		}
+		q.slots[slot] = votes
+	}
+	if slot > q.highestSlot {
+		q.highestSlot = slot
+		q.prune()
+	}
+
+	hash := headerInfo.Header.Hash()
+	_, known := votes.headers[hash]
+	if !known {
+		votes.headers[hash] = headerInfo
+		votes.voters[hash] = make(map[string]bool)
+	}
+	votes.voters[hash][endpoint] = true
+
+	var divergence *types.PandoraHeaderDivergence
+	if !known && len(votes.headers) > 1 {
+		divergence = votes.divergence(slot)
+	}
+
+	if votes.forwarded || len(votes.voters[hash]) < q.quorum {
+		return nil, divergence
+	}
+	votes.forwarded = true
+	return headerInfo, divergence
+}
+
+// reset forgets all the votes, so headers reaching the quorum again are forwarded again.
+func (q *headerQuorum) reset() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.slots = make(map[uint64]*slotVotes)
+	q.highestSlot = 0
+}
+
+// prune removes the votes of the slots out of the window. The caller must hold the lock.
+func (q *headerQuorum) prune() {
+	if q.highestSlot <= quorumSlotWindow {
+		return
+	}
+	for slot := range q.slots {
+		if slot < q.highestSlot-quorumSlotWindow {
+			delete(q.slots, slot)
+		}
+	}
+}
+
+// divergence describes the headers reported for the slot so far.
+func (v *slotVotes) divergence(slot uint64) *types.PandoraHeaderDivergence {
+	divergence := &types.PandoraHeaderDivergence{
+		Slot:      slot,
+		Endpoints: make(map[common.Hash][]string, len(v.voters)),
+	}
+	for hash, voters := range v.voters {
+		for endpoint := range voters {
+			divergence.Endpoints[hash] = append(divergence.Endpoints[hash], endpoint)
+		}
+	}
+	return divergence
+}
+
+// startQuorum starts all the member services and forwards the headers which reached the quorum.
+func (s *Service) startQuorum() {
+	s.isRunning = true
+	for _, member := range s.members {
+		headerInfoCh := make(chan *types.PandoraHeaderInfo, 1)
+		sub := member.SubscribeHeaderInfoEvent(headerInfoCh)
+		go s.collectVotes(member.endpoint, headerInfoCh, sub.Err())
+		member.Start()
+	}
+	log.WithField("quorum", s.quorum).WithField("endpoints", s.endpoints).Info("Subscribing to pandora nodes in quorum mode")
+}
+
+// collectVotes counts the headers reported by the member service of the given endpoint.
+func (s *Service) collectVotes(endpoint string, headerInfoCh <-chan *types.PandoraHeaderInfo, errCh <-chan error) {
+	for {
+		select {
+		case headerInfo := <-headerInfoCh:
+			forward, divergence := s.headerVotes.vote(endpoint, headerInfo)
+			if divergence != nil {
+				pandoraHeaderDivergences.Inc()
+				log.WithField("slot", divergence.Slot).WithField("endpoints", divergence.Endpoints).
+					Warn("Pandora nodes reported different headers for the same slot")
+				s.divergenceFeed.Send(divergence)
+			}
+			if forward != nil {
+				log.WithField("slot", forward.Slot).WithField("headerHash", forward.Header.Hash()).
+					Debug("Pandora header reached the quorum")
+				s.pandoraHeaderInfoFeed.Send(forward)
+			}
+		case <-errCh:
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
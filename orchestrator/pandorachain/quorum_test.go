@@ -0,0 +1,137 @@
+package pandorachain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// dialInProcServers dials the in process server registered for the endpoint
+func dialInProcServers(servers map[string]*rpc.Server) DialRPCFn {
+	return func(endpoint string) (*rpc.Client, error) {
+		server, ok := servers[endpoint]
+		if !ok {
+			return nil, errors.Errorf("unknown endpoint %s", endpoint)
+		}
+		return rpc.DialInProc(server), nil
+	}
+}
+
+func TestHeaderQuorum_Vote(t *testing.T) {
+	votes := newHeaderQuorum(2)
+	header := &types.PandoraHeaderInfo{Slot: 1, Header: testutil.NewEth1Header(1)}
+	forkedHeader := testutil.NewEth1Header(1)
+	forkedHeader.GasUsed++
+	forked := &types.PandoraHeaderInfo{Slot: 1, Header: forkedHeader}
+
+	forward, divergence := votes.vote("a", header)
+	assert.Equal(t, (*types.PandoraHeaderInfo)(nil), forward)
+	assert.Equal(t, (*types.PandoraHeaderDivergence)(nil), divergence)
+
+	// the same endpoint is only counted once
+	forward, _ = votes.vote("a", header)
+	assert.Equal(t, (*types.PandoraHeaderInfo)(nil), forward)
+
+	forward, divergence = votes.vote("c", forked)
+	assert.Equal(t, (*types.PandoraHeaderInfo)(nil), forward)
+	require.NotNil(t, divergence)
+	assert.Equal(t, uint64(1), divergence.Slot)
+	assert.DeepEqual(t, []string{"a"}, divergence.Endpoints[header.Header.Hash()])
+	assert.DeepEqual(t, []string{"c"}, divergence.Endpoints[forkedHeader.Hash()])
+
+	forward, divergence = votes.vote("b", header)
+	assert.Equal(t, header, forward)
+	assert.Equal(t, (*types.PandoraHeaderDivergence)(nil), divergence)
+
+	// a header is forwarded once per slot
+	forward, _ = votes.vote("d", forked)
+	assert.Equal(t, (*types.PandoraHeaderInfo)(nil), forward)
+
+	votes.reset()
+	votes.vote("a", header)
+	forward, _ = votes.vote("b", header)
+	assert.Equal(t, header, forward)
+}
+
+func TestHeaderQuorum_Prune(t *testing.T) {
+	votes := newHeaderQuorum(2)
+	votes.vote("a", &types.PandoraHeaderInfo{Slot: 1, Header: testutil.NewEth1Header(1)})
+	votes.vote("a", &types.PandoraHeaderInfo{Slot: quorumSlotWindow + 2, Header: testutil.NewEth1Header(quorumSlotWindow + 2)})
+	assert.Equal(t, 1, len(votes.slots))
+
+	// votes for slots out of the window are ignored
+	forward, _ := votes.vote("b", &types.PandoraHeaderInfo{Slot: 1, Header: testutil.NewEth1Header(1)})
+	assert.Equal(t, (*types.PandoraHeaderInfo)(nil), forward)
+	assert.Equal(t, 1, len(votes.slots))
+}
+
+func TestNewService_InvalidQuorum(t *testing.T) {
+	_, err := NewService(context.Background(), []string{"ws://a"}, "eth", testDB.SetupDB(t),
+		cache.NewPanHeaderCache(), DialRPCClient(), 2, nil)
+	assert.ErrorContains(t, errInvalidQuorum.Error(), err)
+}
+
+// Test_PandoraSvc_Quorum checks that headers are only forwarded once the quorum of pandora nodes reported them
+func Test_PandoraSvc_Quorum(t *testing.T) {
+	ctx := context.Background()
+
+	serverA, panServiceA := SetupInProcServer(t)
+	defer serverA.Stop()
+	serverB, panServiceB := SetupInProcServer(t)
+	defer serverB.Stop()
+	dialRPCFn := dialInProcServers(map[string]*rpc.Server{"ws://a": serverA, "ws://b": serverB})
+
+	panSvc, err := NewService(ctx, []string{"ws://a", "ws://b"}, "eth", testDB.SetupDB(t),
+		cache.NewPanHeaderCache(), dialRPCFn, 2, nil)
+	require.NoError(t, err)
+	headerInfoCh := make(chan *types.PandoraHeaderInfo, 1)
+	headerSub := panSvc.SubscribeHeaderInfoEvent(headerInfoCh)
+	defer headerSub.Unsubscribe()
+	divergenceCh := make(chan *types.PandoraHeaderDivergence, 1)
+	divergenceSub := panSvc.SubscribeHeaderDivergenceEvent(divergenceCh)
+	defer divergenceSub.Unsubscribe()
+
+	panSvc.Start()
+	defer panSvc.Stop()
+	time.Sleep(1 * time.Second)
+	assert.Equal(t, true, panSvc.IsConnected())
+
+	header := testutil.NewEth1Header(1)
+	panServiceA.pendingHeaderCh <- header
+	select {
+	case <-headerInfoCh:
+		t.Fatal("header forwarded before reaching the quorum")
+	case <-time.After(500 * time.Millisecond):
+	}
+	panServiceB.pendingHeaderCh <- header
+	select {
+	case headerInfo := <-headerInfoCh:
+		assert.Equal(t, header.Hash(), headerInfo.Header.Hash())
+	case <-time.After(2 * time.Second):
+		t.Fatal("header not forwarded after reaching the quorum")
+	}
+
+	divergences := promtestutil.ToFloat64(pandoraHeaderDivergences)
+	forkedHeader := testutil.NewEth1Header(2)
+	panServiceA.pendingHeaderCh <- testutil.NewEth1Header(2)
+	forkedHeader.GasUsed++
+	panServiceB.pendingHeaderCh <- forkedHeader
+	select {
+	case divergence := <-divergenceCh:
+		assert.Equal(t, uint64(2), divergence.Slot)
+		assert.Equal(t, 2, len(divergence.Endpoints))
+	case <-time.After(2 * time.Second):
+		t.Fatal("divergence not reported")
+	}
+	assert.Equal(t, divergences+1, promtestutil.ToFloat64(pandoraHeaderDivergences))
+}
@@ -2,26 +2,29 @@ package pandorachain
 
 import (
 	"context"
+	"fmt"
 	"sync"
-	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/backoff"
+	"github.com/lukso-network/lukso-orchestrator/shared/replay"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
 )
 
-// time to wait before trying to reconnect.
-var reConPeriod = 2 * time.Second
+var errInvalidQuorum = errors.New("pandora quorum exceeds the number of pandora endpoints")
 
 // DialRPCFn dials to the given endpoint
 type DialRPCFn func(endpoint string) (*rpc.Client, error)
 
 // Service
-// 	- maintains connection with pandora chain
-//  - maintains db and cache to store the in-coming headers from pandora.
+//   - maintains connection with pandora chain
+//   - maintains db and cache to store the in-coming headers from pandora.
 type Service struct {
 	// service maintenance related attributes
 	isRunning      bool
@@ -31,15 +34,28 @@ type Service struct {
 	runError       error
 
 	// pandora chain related attributes
-	connected bool
-	endpoint  string
-	rpcClient *rpc.Client
-	dialRPCFn DialRPCFn
-	namespace string
+	connected     bool
+	paused        bool            // paused subscription is not re-established automatically
+	endpoints     []string        // all configured pandora endpoints
+	endpointIndex int             // index of the active endpoint, the next one is failed over to
+	mismatched    map[string]bool // endpoints of another network, never failed over to again
+	endpoint      string          // active endpoint
+	rpcClient     *rpc.Client
+	dialRPCFn     DialRPCFn
+	namespace     string
+	backoff       *backoff.Backoff // delays the reconnection attempts
+	recorder      *replay.Recorder // records the raw pending headers when enabled
+
+	// quorum mode: every endpoint is subscribed by a member service and a header is only
+	// forwarded once quorum members reported it
+	quorum         int
+	members        []*Service
+	headerVotes    *headerQuorum
+	divergenceFeed event.Feed
 
 	// subscription
 	conInfoSubErrCh      chan error
-	conInfoSub           *rpc.ClientSubscription
+	conInfoSub           event.Subscription // websocket/ipc subscription or http polling
 	conDisconnect        chan struct{}
 	vanguardSubscription event.Subscription
 
@@ -51,29 +67,57 @@ type Service struct {
 	pandoraHeaderInfoFeed event.Feed
 }
 
-// NewService creates new service with pandora ws or ipc endpoint, pandora service namespace and db
+// NewService creates new service with pandora ws or ipc endpoints, pandora service namespace and db.
+// With a quorum above one, all endpoints are subscribed at once and a header is only forwarded when
+// quorum of them reported it. Otherwise the first endpoint is used and the others are failed over to.
+// The reconnection attempts back off as configured by backoffCfg.
 func NewService(
 	ctx context.Context,
-	endpoint string,
+	endpoints []string,
 	namespace string,
 	db db.Database,
 	cache cache.PandoraHeaderCache,
 	dialRPCFn DialRPCFn,
+	quorum int,
+	backoffCfg *backoff.Config,
 ) (*Service, error) {
+	if quorum > len(endpoints) {
+		return nil, errors.Wrapf(errInvalidQuorum, "quorum %d, endpoints %d", quorum, len(endpoints))
+	}
 
 	ctx, cancel := context.WithCancel(ctx)
 	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
-	return &Service{
+	s := &Service{
 		ctx:             ctx,
 		cancel:          cancel,
-		endpoint:        endpoint,
+		endpoints:       endpoints,
 		dialRPCFn:       dialRPCFn,
 		namespace:       namespace,
+		backoff:         backoff.New("pandora", backoffCfg),
 		conInfoSubErrCh: make(chan error),
 		conDisconnect:   make(chan struct{}),
 		db:              db,
 		cache:           cache,
-	}, nil
+	}
+	if len(endpoints) > 0 {
+		s.endpoint = endpoints[0]
+	}
+	if quorum <= 1 {
+		return s, nil
+	}
+
+	s.quorum = quorum
+	s.headerVotes = newHeaderQuorum(quorum)
+	for i, endpoint := range endpoints {
+		member, err := NewService(ctx, []string{endpoint}, namespace, db, cache, dialRPCFn, 0, backoffCfg)
+		if err != nil {
+			return nil, err
+		}
+		// the endpoint index keeps urls and their credentials out of the metric labels
+		member.backoff = backoff.New(fmt.Sprintf("pandora-%d", i), backoffCfg)
+		s.members = append(s.members, member)
+	}
+	return s, nil
 }
 
 // Start a consensus info fetcher service's main event loop.
@@ -82,11 +126,14 @@ func (s *Service) Start() {
 	if s.endpoint == "" {
 		return
 	}
+	if s.quorum > 1 {
+		s.startQuorum()
+		return
+	}
 	go func() {
 		s.isRunning = true
-		s.waitForConnection()
-		if s.ctx.Err() != nil {
-			log.Info("Context closed, exiting pandora goroutine")
+		if err := s.waitForConnection(); err != nil {
+			log.Info("Exiting pandora goroutine")
 			return
 		}
 		s.run(s.ctx.Done())
@@ -99,6 +146,10 @@ func (s *Service) Stop() error {
 	}
 	s.closeClients()
 	s.scope.Close()
+	s.backoff.Close()
+	for _, member := range s.members {
+		member.Stop()
+	}
 
 	return nil
 }
@@ -112,6 +163,11 @@ func (s *Service) Status() error {
 	if s.runError != nil {
 		return s.runError
 	}
+	for _, member := range s.members {
+		if err := member.Status(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -123,39 +179,43 @@ func (s *Service) closeClients() {
 }
 
 // waitForConnection waits for a connection with pandora chain. Until a successful connection and subscription with
-// pandora chain, it retries again and again.
-func (s *Service) waitForConnection() {
+// pandora chain, it retries with a growing delay and fails over to the next endpoint after every failure. Once the
+// configured number of attempts failed or all the nodes belong to another network, it gives up and the service
+// reports itself unhealthy.
+func (s *Service) waitForConnection() error {
 	log.Debug("Waiting for the connection")
-	var err error
-	if err = s.connectToChain(); err == nil {
-		log.WithField("endpoint", s.endpoint).Info("Connected and subscribed to pandora chain")
-		s.connected = true
-		return
+	err := s.backoff.Retry(s.ctx, func() error {
+		log.WithField("endpoint", s.endpoint).Debug("Dialing pandora node")
+		if err := s.connectToChain(); err != nil {
+			log.WithError(err).Warn("Could not connect or subscribe to pandora chain")
+			s.runError = err
+			s.failover()
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		s.onReconnectStopped(err)
+		return err
 	}
-	log.WithError(err).Warn("Could not connect or subscribe to pandora chain")
-	s.runError = err
-	ticker := time.NewTicker(reConPeriod)
-	defer ticker.Stop()
+	s.setConnected(true)
+	s.runError = nil
+	log.WithField("endpoint", s.endpoint).Info("Connected and subscribed to pandora chain")
+	return nil
+}
 
-	for {
-		select {
-		case <-ticker.C:
-			log.WithField("endpoint", s.endpoint).Debug("Dialing pandora node")
-			var errConnect error
-			if errConnect = s.connectToChain(); errConnect != nil {
-				log.WithError(errConnect).Warn("Could not connect or subscribe to pandora chain")
-				s.runError = errConnect
-				continue
-			}
-			s.connected = true
-			s.runError = nil
-			log.WithField("endpoint", s.endpoint).Info("Connected and subscribed to pandora chain")
-			return
-		case <-s.ctx.Done():
-			log.Info("Received cancelled context, closing existing pandora client connection service")
-			return
-		}
+// onReconnectStopped handles the end of the reconnection attempts without a connection.
+func (s *Service) onReconnectStopped(err error) {
+	if s.ctx.Err() != nil {
+		log.Info("Received cancelled context, closing existing pandora client connection service")
+		return
 	}
+	if errors.Is(err, errNetworkMismatch) {
+		log.WithError(err).Error("Refusing to proceed with a pandora node of another network")
+	} else {
+		log.WithError(err).Error("Gave up connecting to pandora chain")
+	}
+	s.runError = err
 }
 
 // run subscribes to all the services for the ETH1.0 chain.
@@ -174,6 +234,10 @@ func (s *Service) run(done <-chan struct{}) {
 			return
 		case err := <-s.conInfoSubErrCh:
 			log.WithError(err).Debug("Got subscription error")
+			if s.isPaused() {
+				log.Info("Pandora subscription is paused, not retrying to connect")
+				continue
+			}
 			log.Debug("Starting retry to connect and subscribe to pandora chain")
 			// Try to check the connection and retry to establish the connection
 			s.retryToConnectAndSubscribe(err)
@@ -183,25 +247,34 @@ func (s *Service) run(done <-chan struct{}) {
 }
 
 func (s *Service) StopPandoraSubscription() {
+	if s.quorum > 1 {
+		for _, member := range s.members {
+			member.StopPandoraSubscription()
+		}
+		return
+	}
 	defer log.Info("Pandora subscription stopped")
-	if s.conInfoSub != nil {
-		s.conInfoSub.Unsubscribe()
+	s.processingLock.RLock()
+	sub := s.conInfoSub
+	s.processingLock.RUnlock()
+	if sub != nil {
+		sub.Unsubscribe()
 	}
 }
 
 func (s *Service) ResumePandoraSubscription() error {
+	if s.quorum > 1 {
+		s.headerVotes.reset()
+		return s.forEachMember((*Service).ResumePandoraSubscription)
+	}
 	defer log.Info("Pandora subscription resumed")
 	return s.subscribe()
 }
 
 // connectToChain dials to pandora chain and creates rpcClient and subscribe
 func (s *Service) connectToChain() error {
-	if s.rpcClient == nil {
-		panRPCClient, err := s.dialRPCFn(s.endpoint)
-		if err != nil {
-			return err
-		}
-		s.rpcClient = panRPCClient
+	if err := s.dialClient(); err != nil {
+		return err
 	}
 
 	// connect to pandora subscription
@@ -211,37 +284,285 @@ func (s *Service) connectToChain() error {
 	return nil
 }
 
+// dialClient dials the active pandora endpoint unless a client is already connected, and verifies
+// the network of the node.
+func (s *Service) dialClient() error {
+	s.processingLock.RLock()
+	connected := s.rpcClient != nil
+	endpoint := s.endpoint
+	s.processingLock.RUnlock()
+	if connected {
+		return nil
+	}
+
+	panRPCClient, err := s.dialRPCFn(endpoint)
+	if err != nil {
+		return err
+	}
+	if err := s.verifyNetwork(panRPCClient, endpoint); err != nil {
+		panRPCClient.Close()
+		if errors.Is(err, errNetworkMismatch) && s.excludeEndpoint(endpoint) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+	s.processingLock.Lock()
+	s.rpcClient = panRPCClient
+	s.processingLock.Unlock()
+	return nil
+}
+
+// excludeEndpoint stops failing over to an endpoint of another network. It returns true once no
+// endpoint of the pinned network is left.
+func (s *Service) excludeEndpoint(endpoint string) bool {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	if s.mismatched == nil {
+		s.mismatched = make(map[string]bool)
+	}
+	s.mismatched[endpoint] = true
+	log.WithField("endpoint", endpoint).Error("Excluding the pandora endpoint of another network")
+	for _, endpoint := range s.endpoints {
+		if !s.mismatched[endpoint] {
+			return false
+		}
+	}
+	return true
+}
+
+// failover drops the client and the subscription of the active pandora endpoint and makes the next
+// configured endpoint of the pinned network the active one.
+func (s *Service) failover() {
+	s.processingLock.Lock()
+	client := s.rpcClient
+	s.rpcClient = nil
+	s.conInfoSub = nil
+	previous := s.endpoint
+	for range s.endpoints {
+		s.endpointIndex = (s.endpointIndex + 1) % len(s.endpoints)
+		s.endpoint = s.endpoints[s.endpointIndex]
+		if !s.mismatched[s.endpoint] {
+			break
+		}
+	}
+	endpoint := s.endpoint
+	s.processingLock.Unlock()
+
+	if client != nil {
+		client.Close()
+	}
+	if previous != endpoint {
+		pandoraFailovers.Inc()
+		log.WithField("from", previous).WithField("to", endpoint).Warn("Failing over to another pandora endpoint")
+	}
+}
+
+// forEachMember calls fn for every member service of the quorum and returns the first error.
+func (s *Service) forEachMember(fn func(member *Service) error) error {
+	var firstErr error
+	for _, member := range s.members {
+		if err := fn(member); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
 // retryToConnectAndSubscribe retries to pandora chain in case of any failure.
 func (s *Service) retryToConnectAndSubscribe(err error) {
 	s.runError = err
-	s.connected = false
-	// Back off for a while before resuming dialing the pandora node.
-	time.Sleep(reConPeriod)
-	go s.waitForConnection()
-	// Reset run error in the event of a successful connection.
-	s.runError = nil
+	s.setConnected(false)
+	s.failover()
+	pandoraReconnects.Inc()
+	// Back off for a while before resuming dialing the pandora node, without blocking the caller.
+	go func() {
+		if err := s.backoff.Wait(s.ctx); err != nil {
+			s.onReconnectStopped(err)
+			return
+		}
+		s.waitForConnection()
+	}()
 }
 
 // subscribe subscribes to pandora events
 func (s *Service) subscribe() error {
-	latestSavedHeaderHash := s.db.LatestVerifiedHeaderHash()
+	log.WithField("finalizedSlot", s.db.LatestSavedVerifiedSlot()).
+		Debug("Start subscribing to pandora client for pending headers")
+	return s.subscribeFrom(s.db.LatestVerifiedHeaderHash())
+}
+
+// subscribeFrom subscribes to pandora pending headers starting after the given header hash and
+// replaces the previous subscription, if any.
+func (s *Service) subscribeFrom(fromHeaderHash common.Hash) error {
 	filter := &types.PandoraPendingHeaderFilter{
-		FromBlockHash: latestSavedHeaderHash,
+		FromBlockHash: fromHeaderHash,
 	}
+	log.WithField("panHeaderHash", filter.FromBlockHash).Debug("Subscribing to pandora client for pending headers")
 
-	log.WithField("finalizedSlot", s.db.LatestSavedVerifiedSlot()).WithField("panHeaderHash", filter.FromBlockHash).
-		Debug("Start subscribing to pandora client for pending headers")
-
-	// subscribe to pandora client for pending headers
-	sub, err := s.SubscribePendingHeaders(s.ctx, filter, s.namespace, s.rpcClient)
+	// subscribe to pandora client for pending headers, http endpoints do not support subscriptions
+	var (
+		sub event.Subscription
+		err error
+	)
+	if isHTTPEndpoint(s.endpoint) {
+		sub, err = s.PollPendingHeaders(s.ctx, filter, s.namespace, s.rpcClient)
+	} else {
+		sub, err = s.SubscribePendingHeaders(s.ctx, filter, s.namespace, s.rpcClient)
+	}
 	if err != nil {
 		log.WithError(err).Warn("Could not subscribe to pandora client for new pending headers")
 		return err
 	}
+
+	s.processingLock.Lock()
+	previousSub := s.conInfoSub
 	s.conInfoSub = sub
+	s.processingLock.Unlock()
+
+	if previousSub != nil {
+		previousSub.Unsubscribe()
+	}
+	return nil
+}
+
+// PauseSubscription stops the pandora subscription until ResumeSubscription is called.
+func (s *Service) PauseSubscription() {
+	if s.quorum > 1 {
+		for _, member := range s.members {
+			member.PauseSubscription()
+		}
+		return
+	}
+	s.processingLock.Lock()
+	s.paused = true
+	s.processingLock.Unlock()
+
+	s.StopPandoraSubscription()
+	s.setConnected(false)
+	log.Info("Paused pandora subscription")
+}
+
+// ResumeSubscription re-subscribes a paused pandora subscription from the latest verified header.
+func (s *Service) ResumeSubscription() error {
+	if s.quorum > 1 {
+		s.headerVotes.reset()
+		return s.forEachMember((*Service).ResumeSubscription)
+	}
+	if !s.isPaused() {
+		return errSubscriptionNotPaused
+	}
+	return s.ResubscribeFrom(s.db.LatestSavedVerifiedSlot())
+}
+
+// ResubscribeFrom replaces the running pandora subscription by a new one which starts after
+// the verified pandora header of the given slot.
+func (s *Service) ResubscribeFrom(fromSlot uint64) error {
+	if s.quorum > 1 {
+		s.headerVotes.reset()
+		return s.forEachMember(func(member *Service) error {
+			return member.ResubscribeFrom(fromSlot)
+		})
+	}
+	slotInfo, err := s.db.VerifiedSlotInfo(fromSlot)
+	if err != nil {
+		return err
+	}
+	if slotInfo == nil {
+		return errors.Wrapf(errMissingVerifiedSlot, "slot %d", fromSlot)
+	}
+
+	if err := s.dialClient(); err != nil {
+		return err
+	}
+
+	s.processingLock.Lock()
+	s.paused = false
+	s.processingLock.Unlock()
+
+	if err := s.subscribeFrom(slotInfo.PandoraHeaderHash); err != nil {
+		return err
+	}
+	s.setConnected(true)
+	pandoraReconnects.Inc()
+	log.WithField("fromSlot", fromSlot).Info("Re-subscribed to pandora")
 	return nil
 }
 
+func (s *Service) isPaused() bool {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	return s.paused
+}
+
+// isReplacedSubscription returns true when the given subscription is not the running one anymore.
+func (s *Service) isReplacedSubscription(sub event.Subscription) bool {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	return s.conInfoSub != sub
+}
+
+// setConnected updates the pandora connection state along with its metric.
+func (s *Service) setConnected(connected bool) {
+	s.processingLock.Lock()
+	s.connected = connected
+	s.processingLock.Unlock()
+
+	if connected {
+		pandoraConnected.Set(1)
+		return
+	}
+	pandoraConnected.Set(0)
+}
+
+// IsConnected returns true when the orchestrator is connected and subscribed to the pandora node.
+// In quorum mode, at least quorum pandora nodes must be connected.
+func (s *Service) IsConnected() bool {
+	if s.quorum > 1 {
+		connected := 0
+		for _, member := range s.members {
+			if member.IsConnected() {
+				connected++
+			}
+		}
+		return connected >= s.quorum
+	}
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	return s.connected
+}
+
+// SetRecorder records the raw headers received from the pandora nodes. It must be called before Start.
+// In quorum mode, a header reported by several nodes is recorded once.
+func (s *Service) SetRecorder(recorder *replay.Recorder) {
+	s.recorder = recorder
+	for _, member := range s.members {
+		member.SetRecorder(recorder)
+	}
+}
+
 func (s *Service) SubscribeHeaderInfoEvent(ch chan<- *types.PandoraHeaderInfo) event.Subscription {
 	return s.scope.Track(s.pandoraHeaderInfoFeed.Subscribe(ch))
 }
+
+// SubscribeHeaderDivergenceEvent registers a subscription of pandora nodes disagreeing about the header of a slot.
+func (s *Service) SubscribeHeaderDivergenceEvent(ch chan<- *types.PandoraHeaderDivergence) event.Subscription {
+	return s.scope.Track(s.divergenceFeed.Subscribe(ch))
+}
+
+// SubscribeReconnectEvent registers a subscription of the attempts to reconnect with the pandora nodes.
+func (s *Service) SubscribeReconnectEvent(ch chan<- *backoff.Event) event.Subscription {
+	if s.quorum <= 1 {
+		return s.backoff.SubscribeEvent(ch)
+	}
+	subs := make([]event.Subscription, 0, len(s.members))
+	for _, member := range s.members {
+		subs = append(subs, member.backoff.SubscribeEvent(ch))
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		for _, sub := range subs {
+			sub.Unsubscribe()
+		}
+		return nil
+	})
+}
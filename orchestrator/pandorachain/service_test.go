@@ -2,6 +2,9 @@ package pandorachain
 
 import (
 	"context"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
 	"github.com/pkg/errors"
 	logTest "github.com/sirupsen/logrus/hooks/test"
@@ -14,7 +17,6 @@ import (
 func Test_PandoraSvc_StartStop(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ctx := context.Background()
-	reConPeriod = 1 * time.Second
 
 	inProcServer, _ := SetupInProcServer(t)
 	defer inProcServer.Stop()
@@ -33,7 +35,6 @@ func Test_PandoraSvc_StartStop(t *testing.T) {
 func Test_PandoraSvc_RetrySub(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ctx := context.Background()
-	reConPeriod = 1 * time.Second
 
 	inProcServer, _ := SetupInProcServer(t)
 	defer inProcServer.Stop()
@@ -51,3 +52,26 @@ func Test_PandoraSvc_RetrySub(t *testing.T) {
 	hook.Reset()
 	assert.NoError(t, panSvc.Stop())
 }
+
+// Test_PandoraSvc_Failover checks that the next pandora endpoint is used when the active one is unreachable.
+func Test_PandoraSvc_Failover(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+
+	inProcServer, _ := SetupInProcServer(t)
+	defer inProcServer.Stop()
+	dialRPCFn := dialInProcServers(map[string]*rpc.Server{"ws://b": inProcServer})
+
+	panSvc, err := NewService(ctx, []string{"ws://a", "ws://b"}, "eth", testDB.SetupDB(t),
+		cache.NewPanHeaderCache(), dialRPCFn, 0, nil)
+	assert.NoError(t, err)
+	panSvc.Start()
+
+	time.Sleep(2 * time.Second)
+	assert.LogsContain(t, hook, "Failing over to another pandora endpoint")
+	assert.LogsContain(t, hook, "Connected and subscribed to pandora chain")
+	assert.Equal(t, "ws://b", panSvc.endpoint)
+
+	hook.Reset()
+	assert.NoError(t, panSvc.Stop())
+}
@@ -11,6 +11,8 @@ import (
 
 var (
 	errPandoraHeaderProcessing = errors.New("Failed to process the pending pandora header")
+	errSubscriptionNotPaused   = errors.New("pandora subscription is not paused")
+	errMissingVerifiedSlot     = errors.New("verified slot info not found")
 )
 
 // subscribePendingHeaders subscribes to pandora client from latest saved slot using given rpc client
@@ -44,6 +46,10 @@ func (s *Service) SubscribePendingHeaders(
 				return
 			case err := <-sub.Err():
 				log.WithError(err).Debug("Got subscription error")
+				if s.isReplacedSubscription(sub) {
+					log.Debug("Pandora subscription has been replaced, exiting pending block subscription")
+					return
+				}
 				s.conInfoSubErrCh <- err
 				return
 			case <-ctx.Done():
@@ -16,7 +16,6 @@ import (
 func Test_PandoraSvc_PendingHeaderSub(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ctx := context.Background()
-	reConPeriod = 1 * time.Second
 
 	inProcServer, panService := SetupInProcServer(t)
 	defer inProcServer.Stop()
@@ -2,14 +2,19 @@ package pandorachain
 
 import (
 	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"math/big"
+	"sync"
 	"testing"
 )
 
@@ -17,6 +22,87 @@ import (
 type pandoraChainService struct {
 	unsubscribed    chan string
 	pendingHeaderCh chan *eth1Types.Header
+	chainID         uint64
+	genesis         *eth1Types.Header
+
+	pendingHeadersLock sync.Mutex
+	pendingHeaders     []*eth1Types.Header
+	pollBlock          chan struct{} // GetPendingBlockHeaders hangs until it is closed, when set
+}
+
+// ChainId returns the chain id of the mocked pandora network
+func (s *pandoraChainService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int).SetUint64(s.chainID))
+}
+
+// GetBlockByNumber serves the genesis header and the pending headers of the mocked pandora network
+func (s *pandoraChainService) GetBlockByNumber(
+	ctx context.Context, number rpc.BlockNumber, fullTx bool,
+) (*eth1Types.Header, error) {
+	if number == 0 {
+		return s.genesis, nil
+	}
+	s.pendingHeadersLock.Lock()
+	defer s.pendingHeadersLock.Unlock()
+	for _, header := range s.pendingHeaders {
+		if header.Number.Int64() == number.Int64() {
+			return header, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetBlockByHash serves the pending headers of the mocked pandora network
+func (s *pandoraChainService) GetBlockByHash(
+	ctx context.Context, hash common.Hash, fullTx bool,
+) (*eth1Types.Header, error) {
+	s.pendingHeadersLock.Lock()
+	defer s.pendingHeadersLock.Unlock()
+	for _, header := range s.pendingHeaders {
+		if header.Hash() == hash {
+			return header, nil
+		}
+	}
+	return nil, nil
+}
+
+// blockPolls makes GetPendingBlockHeaders hang until the returned channel is closed
+func (s *pandoraChainService) blockPolls() chan struct{} {
+	s.pendingHeadersLock.Lock()
+	defer s.pendingHeadersLock.Unlock()
+	s.pollBlock = make(chan struct{})
+	return s.pollBlock
+}
+
+// addPendingHeader adds a header to the ones returned by GetPendingBlockHeaders
+func (s *pandoraChainService) addPendingHeader(header *eth1Types.Header) {
+	s.pendingHeadersLock.Lock()
+	defer s.pendingHeadersLock.Unlock()
+	s.pendingHeaders = append(s.pendingHeaders, header)
+}
+
+// GetPendingBlockHeaders returns the pending headers following the header of the filter
+func (s *pandoraChainService) GetPendingBlockHeaders(
+	ctx context.Context, filter types.PandoraPendingHeaderFilter,
+) ([]*eth1Types.Header, error) {
+	s.pendingHeadersLock.Lock()
+	pollBlock := s.pollBlock
+	s.pendingHeadersLock.Unlock()
+	if pollBlock != nil {
+		select {
+		case <-pollBlock:
+		case <-ctx.Done():
+		}
+	}
+
+	s.pendingHeadersLock.Lock()
+	defer s.pendingHeadersLock.Unlock()
+	for i, header := range s.pendingHeaders {
+		if header.Hash() == filter.FromBlockHash {
+			return s.pendingHeaders[i+1:], nil
+		}
+	}
+	return s.pendingHeaders, nil
 }
 
 // Unsubscribe
@@ -67,6 +153,8 @@ func SetupInProcServer(t *testing.T) (*rpc.Server, *pandoraChainService) {
 	panService := &pandoraChainService{
 		unsubscribed:    make(chan string),
 		pendingHeaderCh: make(chan *eth1Types.Header),
+		chainID:         1,
+		genesis:         testutil.NewEth1Header(0),
 	}
 	if err := server.RegisterName("eth", panService); err != nil {
 		panic(err)
@@ -82,11 +170,13 @@ func SetupPandoraSvc(ctx context.Context, t *testing.T, dialRPCFn DialRPCFn) *Se
 
 	svc, err := NewService(
 		ctx,
-		"ws://127.0.0.1:8546",
+		[]string{"ws://127.0.0.1:8546"},
 		"eth",
 		testDB.SetupDB(t),
 		cache.NewPanHeaderCache(),
-		dialRPCFn)
+		dialRPCFn,
+		0,
+		nil)
 	if err != nil {
 		t.Fatalf("failed to create protocol stack: %v", err)
 	}
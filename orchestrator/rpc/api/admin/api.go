@@ -0,0 +1,242 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/events"
+	"github.com/lukso-network/lukso-orchestrator/shared/backoff"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/sirupsen/logrus"
+)
+
+var errNotAvailable = errors.New("not available on this node")
+
+// VanguardController controls the vanguard chain subscriptions
+type VanguardController interface {
+	PauseSubscription()
+	ResumeSubscription() error
+	ResubscribeFrom(fromSlot, fromEpoch uint64) error
+}
+
+// PandoraController controls the pandora chain subscription
+type PandoraController interface {
+	PauseSubscription()
+	ResumeSubscription() error
+	ResubscribeFrom(fromSlot uint64) error
+}
+
+// SlotReverter reverts verified slot infos to a given slot
+type SlotReverter interface {
+	RevertToSlot(slot uint64) error
+}
+
+// ReconnectEventFeed notifies the attempts to reconnect with a chain node
+type ReconnectEventFeed interface {
+	SubscribeReconnectEvent(ch chan<- *backoff.Event) event.Subscription
+}
+
+// HeaderDivergenceFeed notifies pandora nodes disagreeing about the header of a slot
+type HeaderDivergenceFeed interface {
+	SubscribeHeaderDivergenceEvent(ch chan<- *types.PandoraHeaderDivergence) event.Subscription
+}
+
+// Config holds everything the admin api operates on
+type Config struct {
+	VanguardController           VanguardController
+	PandoraController            PandoraController
+	SlotReverter                 SlotReverter
+	VanguardPendingShardingCache cache.VanguardShardCache
+	PandoraPendingHeaderCache    cache.PandoraHeaderCache
+	Subscribers                  *events.SubscriberRegistry
+	ReconnectEventFeeds          []ReconnectEventFeed
+	HeaderDivergenceFeed         HeaderDivergenceFeed
+}
+
+// PrivateAdminAPI offers runtime control over a running orchestrator node. It must only be
+// exposed over authenticated transports.
+type PrivateAdminAPI struct {
+	cfg *Config
+}
+
+// NewPrivateAdminAPI creates a new admin api instance
+func NewPrivateAdminAPI(cfg *Config) *PrivateAdminAPI {
+	return &PrivateAdminAPI{cfg: cfg}
+}
+
+// PauseVanguardSubscription stops the vanguard subscriptions until they are resumed
+func (api *PrivateAdminAPI) PauseVanguardSubscription() (bool, error) {
+	if api.cfg.VanguardController == nil {
+		return false, errNotAvailable
+	}
+	api.cfg.VanguardController.PauseSubscription()
+	return true, nil
+}
+
+// ResumeVanguardSubscription resumes paused vanguard subscriptions from the latest finalized slot and epoch
+func (api *PrivateAdminAPI) ResumeVanguardSubscription() (bool, error) {
+	if api.cfg.VanguardController == nil {
+		return false, errNotAvailable
+	}
+	if err := api.cfg.VanguardController.ResumeSubscription(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ResubscribeVanguard forces vanguard subscriptions to start again from the given slot and epoch
+func (api *PrivateAdminAPI) ResubscribeVanguard(fromSlot, fromEpoch uint64) (bool, error) {
+	if api.cfg.VanguardController == nil {
+		return false, errNotAvailable
+	}
+	if err := api.cfg.VanguardController.ResubscribeFrom(fromSlot, fromEpoch); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PausePandoraSubscription stops the pandora subscription until it is resumed
+func (api *PrivateAdminAPI) PausePandoraSubscription() (bool, error) {
+	if api.cfg.PandoraController == nil {
+		return false, errNotAvailable
+	}
+	api.cfg.PandoraController.PauseSubscription()
+	return true, nil
+}
+
+// ResumePandoraSubscription resumes a paused pandora subscription from the latest verified slot
+func (api *PrivateAdminAPI) ResumePandoraSubscription() (bool, error) {
+	if api.cfg.PandoraController == nil {
+		return false, errNotAvailable
+	}
+	if err := api.cfg.PandoraController.ResumeSubscription(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ResubscribePandora forces the pandora subscription to start again after the verified header of the given slot
+func (api *PrivateAdminAPI) ResubscribePandora(fromSlot uint64) (bool, error) {
+	if api.cfg.PandoraController == nil {
+		return false, errNotAvailable
+	}
+	if err := api.cfg.PandoraController.ResubscribeFrom(fromSlot); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PurgePendingCaches removes all pending vanguard sharding infos and pandora headers
+func (api *PrivateAdminAPI) PurgePendingCaches() (bool, error) {
+	if api.cfg.VanguardPendingShardingCache == nil || api.cfg.PandoraPendingHeaderCache == nil {
+		return false, errNotAvailable
+	}
+	api.cfg.VanguardPendingShardingCache.Purge()
+	api.cfg.PandoraPendingHeaderCache.Purge()
+	log.Warn("Purged pending vanguard and pandora caches")
+	return true, nil
+}
+
+// RevertToSlot removes verified slot infos after the given slot and re-subscribes both chains from there
+func (api *PrivateAdminAPI) RevertToSlot(slot uint64) (bool, error) {
+	if api.cfg.SlotReverter == nil {
+		return false, errNotAvailable
+	}
+	if err := api.cfg.SlotReverter.RevertToSlot(slot); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetVerbosity changes the log verbosity of the node at runtime
+func (api *PrivateAdminAPI) SetVerbosity(verbosity string) (bool, error) {
+	level, err := logrus.ParseLevel(verbosity)
+	if err != nil {
+		return false, err
+	}
+	logrus.SetLevel(level)
+	log.WithField("verbosity", level.String()).Info("Changed log verbosity")
+	return true, nil
+}
+
+// Subscribers lists the active RPC subscriptions
+func (api *PrivateAdminAPI) Subscribers() ([]*events.Subscriber, error) {
+	if api.cfg.Subscribers == nil {
+		return nil, errNotAvailable
+	}
+	return api.cfg.Subscribers.List(), nil
+}
+
+// ReconnectEvents streams the attempts to reconnect with the vanguard and pandora nodes
+func (api *PrivateAdminAPI) ReconnectEvents(ctx context.Context) (*rpc.Subscription, error) {
+	if len(api.cfg.ReconnectEventFeeds) == 0 {
+		return &rpc.Subscription{}, errNotAvailable
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	eventCh := make(chan *backoff.Event, len(api.cfg.ReconnectEventFeeds))
+	subs := make([]event.Subscription, 0, len(api.cfg.ReconnectEventFeeds))
+	for _, feed := range api.cfg.ReconnectEventFeeds {
+		subs = append(subs, feed.SubscribeReconnectEvent(eventCh))
+	}
+	go func() {
+		defer func() {
+			for _, sub := range subs {
+				sub.Unsubscribe()
+			}
+		}()
+		for {
+			select {
+			case ev := <-eventCh:
+				if err := notifier.Notify(rpcSub.ID, ev); err != nil {
+					log.WithError(err).Error("Failed to notify reconnect event")
+					return
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// HeaderDivergences streams the slots whose header differs between the pandora nodes of the quorum
+func (api *PrivateAdminAPI) HeaderDivergences(ctx context.Context) (*rpc.Subscription, error) {
+	if api.cfg.HeaderDivergenceFeed == nil {
+		return &rpc.Subscription{}, errNotAvailable
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	divergenceCh := make(chan *types.PandoraHeaderDivergence, 1)
+	divergenceSub := api.cfg.HeaderDivergenceFeed.SubscribeHeaderDivergenceEvent(divergenceCh)
+	go func() {
+		defer divergenceSub.Unsubscribe()
+		for {
+			select {
+			case divergence := <-divergenceCh:
+				if err := notifier.Notify(rpcSub.ID, divergence); err != nil {
+					log.WithError(err).Error("Failed to notify header divergence")
+					return
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
@@ -0,0 +1,238 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/events"
+	"github.com/lukso-network/lukso-orchestrator/shared/backoff"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/sirupsen/logrus"
+)
+
+type mockController struct {
+	paused    bool
+	fromSlot  uint64
+	fromEpoch uint64
+	err       error
+}
+
+func (m *mockController) PauseSubscription() {
+	m.paused = true
+}
+
+func (m *mockController) ResumeSubscription() error {
+	if m.err != nil {
+		return m.err
+	}
+	m.paused = false
+	return nil
+}
+
+func (m *mockController) ResubscribeFrom(fromSlot uint64) error {
+	m.fromSlot = fromSlot
+	return m.err
+}
+
+type mockVanguardController struct {
+	mockController
+}
+
+func (m *mockVanguardController) ResubscribeFrom(fromSlot, fromEpoch uint64) error {
+	m.fromSlot, m.fromEpoch = fromSlot, fromEpoch
+	return m.err
+}
+
+type mockSlotReverter struct {
+	revertedSlot uint64
+}
+
+func (m *mockSlotReverter) RevertToSlot(slot uint64) error {
+	m.revertedSlot = slot
+	return nil
+}
+
+type mockReconnectFeed struct {
+	feed event.Feed
+}
+
+func (m *mockReconnectFeed) SubscribeReconnectEvent(ch chan<- *backoff.Event) event.Subscription {
+	return m.feed.Subscribe(ch)
+}
+
+// dialAdminAPI serves the given admin api in process and returns a client of it.
+func dialAdminAPI(t *testing.T, api *PrivateAdminAPI) *rpc.Client {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("admin", api))
+	client := rpc.DialInProc(server)
+	t.Cleanup(client.Close)
+	return client
+}
+
+type mockDivergenceFeed struct {
+	feed event.Feed
+}
+
+func (m *mockDivergenceFeed) SubscribeHeaderDivergenceEvent(ch chan<- *types.PandoraHeaderDivergence) event.Subscription {
+	return m.feed.Subscribe(ch)
+}
+
+func TestPrivateAdminAPI_Subscriptions(t *testing.T) {
+	vanguard := &mockVanguardController{}
+	pandora := &mockController{}
+	api := NewPrivateAdminAPI(&Config{
+		VanguardController: vanguard,
+		PandoraController:  pandora,
+	})
+
+	_, err := api.PauseVanguardSubscription()
+	require.NoError(t, err)
+	_, err = api.PausePandoraSubscription()
+	require.NoError(t, err)
+	assert.Equal(t, true, vanguard.paused)
+	assert.Equal(t, true, pandora.paused)
+
+	_, err = api.ResumeVanguardSubscription()
+	require.NoError(t, err)
+	_, err = api.ResumePandoraSubscription()
+	require.NoError(t, err)
+	assert.Equal(t, false, vanguard.paused)
+	assert.Equal(t, false, pandora.paused)
+
+	_, err = api.ResubscribeVanguard(64, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(64), vanguard.fromSlot)
+	assert.Equal(t, uint64(2), vanguard.fromEpoch)
+
+	pandora.err = errors.New("verified slot info not found")
+	ok, err := api.ResubscribePandora(70)
+	assert.ErrorContains(t, "verified slot info not found", err)
+	assert.Equal(t, false, ok)
+}
+
+func TestPrivateAdminAPI_NotAvailable(t *testing.T) {
+	api := NewPrivateAdminAPI(&Config{})
+	_, err := api.PauseVanguardSubscription()
+	assert.ErrorContains(t, errNotAvailable.Error(), err)
+	_, err = api.RevertToSlot(10)
+	assert.ErrorContains(t, errNotAvailable.Error(), err)
+	_, err = api.PurgePendingCaches()
+	assert.ErrorContains(t, errNotAvailable.Error(), err)
+}
+
+func TestPrivateAdminAPI_PurgePendingCaches(t *testing.T) {
+	ctx := context.Background()
+	vanCache := cache.NewVanShardInfoCache(1024)
+	panCache := cache.NewPanHeaderCache()
+	require.NoError(t, vanCache.Put(ctx, 1, &types.VanguardShardInfo{Slot: 1}))
+	require.NoError(t, panCache.Put(ctx, 1, testutil.NewEth1Header(1)))
+
+	api := NewPrivateAdminAPI(&Config{
+		VanguardPendingShardingCache: vanCache,
+		PandoraPendingHeaderCache:    panCache,
+	})
+	_, err := api.PurgePendingCaches()
+	require.NoError(t, err)
+
+	_, err = vanCache.Get(ctx, 1)
+	assert.NotNil(t, err)
+	_, err = panCache.Get(ctx, 1)
+	assert.NotNil(t, err)
+}
+
+func TestPrivateAdminAPI_RevertToSlot(t *testing.T) {
+	reverter := &mockSlotReverter{}
+	api := NewPrivateAdminAPI(&Config{SlotReverter: reverter})
+	_, err := api.RevertToSlot(42)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), reverter.revertedSlot)
+}
+
+func TestPrivateAdminAPI_SetVerbosity(t *testing.T) {
+	level := logrus.GetLevel()
+	defer logrus.SetLevel(level)
+
+	api := NewPrivateAdminAPI(&Config{})
+	_, err := api.SetVerbosity("debug")
+	require.NoError(t, err)
+	assert.Equal(t, logrus.DebugLevel, logrus.GetLevel())
+
+	_, err = api.SetVerbosity("chatty")
+	assert.NotNil(t, err)
+	assert.Equal(t, logrus.DebugLevel, logrus.GetLevel())
+}
+
+func TestPrivateAdminAPI_Subscribers(t *testing.T) {
+	registry := events.NewSubscriberRegistry()
+	api := NewPrivateAdminAPI(&Config{Subscribers: registry})
+
+	subscribers, err := api.Subscribers()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(subscribers))
+}
+
+func TestPrivateAdminAPI_ReconnectEvents(t *testing.T) {
+	vanguard, pandora := &mockReconnectFeed{}, &mockReconnectFeed{}
+	client := dialAdminAPI(t, NewPrivateAdminAPI(&Config{
+		ReconnectEventFeeds: []ReconnectEventFeed{vanguard, pandora},
+	}))
+
+	eventCh := make(chan *backoff.Event, 2)
+	sub, err := client.Subscribe(context.Background(), "admin", eventCh, "reconnectEvents")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	// the subscription is registered in the background, events are dropped until then
+	for pandora.feed.Send(&backoff.Event{Component: "pandora", Attempt: 1}) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	vanguard.feed.Send(&backoff.Event{Component: "vanguard", Attempt: 2, Connected: true})
+	for _, component := range []string{"pandora", "vanguard"} {
+		select {
+		case ev := <-eventCh:
+			assert.Equal(t, component, ev.Component)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s reconnect event not received", component)
+		}
+	}
+
+	_, err = dialAdminAPI(t, NewPrivateAdminAPI(&Config{})).
+		Subscribe(context.Background(), "admin", eventCh, "reconnectEvents")
+	assert.ErrorContains(t, errNotAvailable.Error(), err)
+}
+
+func TestPrivateAdminAPI_HeaderDivergences(t *testing.T) {
+	pandora := &mockDivergenceFeed{}
+	client := dialAdminAPI(t, NewPrivateAdminAPI(&Config{HeaderDivergenceFeed: pandora}))
+
+	divergenceCh := make(chan *types.PandoraHeaderDivergence, 1)
+	sub, err := client.Subscribe(context.Background(), "admin", divergenceCh, "headerDivergences")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	pandora.feed.Send(&types.PandoraHeaderDivergence{Slot: 7, Endpoints: map[common.Hash][]string{
+		common.HexToHash("0x01"): {"pandora-0"},
+		common.HexToHash("0x02"): {"pandora-1"},
+	}})
+	select {
+	case divergence := <-divergenceCh:
+		assert.Equal(t, uint64(7), divergence.Slot)
+		assert.DeepEqual(t, []string{"pandora-1"}, divergence.Endpoints[common.HexToHash("0x02")])
+	case <-time.After(5 * time.Second):
+		t.Fatal("header divergence not received")
+	}
+
+	_, err = dialAdminAPI(t, NewPrivateAdminAPI(&Config{})).
+		Subscribe(context.Background(), "admin", divergenceCh, "headerDivergences")
+	assert.ErrorContains(t, errNotAvailable.Error(), err)
+}
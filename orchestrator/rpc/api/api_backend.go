@@ -21,6 +21,7 @@ type Backend struct {
 	// feed
 	ConsensusInfoFeed    iface.ConsensusInfoFeed
 	VerifiedSlotInfoFeed conIface.VerifiedSlotInfoFeed
+	SyncStatusReader     conIface.SyncStatusReader
 
 	// db reference
 	ConsensusInfoDB    db.ROnlyConsensusInfoDB
@@ -82,6 +83,14 @@ func (backend *Backend) LatestFinalizedSlot() uint64 {
 	return backend.VerifiedSlotInfoDB.LatestLatestFinalizedSlot()
 }
 
+// SyncStatus returns the current sync status of the orchestrator
+func (backend *Backend) SyncStatus() (*types.SyncStatus, error) {
+	if backend.SyncStatusReader == nil {
+		return nil, errors.New("sync status is not available")
+	}
+	return backend.SyncStatusReader.SyncStatus(), nil
+}
+
 // GetSlotStatus
 func (backend *Backend) GetSlotStatus(ctx context.Context, slot uint64, hash common.Hash, requestFrom bool) types.Status {
 	// by default if nothing is found then return skipped
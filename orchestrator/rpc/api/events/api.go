@@ -25,14 +25,16 @@ type Backend interface {
 	LatestVerifiedSlot() uint64
 	PendingPandoraHeaders() []*eth1Types.Header
 	LatestFinalizedSlot() uint64
+	SyncStatus() (*generalTypes.SyncStatus, error)
 }
 
 // PublicFilterAPI offers support to create and manage filters. This will allow external clients to retrieve various
 // information related to the Ethereum protocol such als blocks, transactions and logs.
 type PublicFilterAPI struct {
-	backend Backend
-	events  *EventSystem
-	timeout time.Duration
+	backend     Backend
+	events      *EventSystem
+	timeout     time.Duration
+	subscribers *SubscriberRegistry
 }
 
 type BlockHash struct {
@@ -45,12 +47,17 @@ type BlockStatus struct {
 	Status generalTypes.Status
 }
 
-// NewPublicFilterAPI returns a new PublicFilterAPI instance.
-func NewPublicFilterAPI(backend Backend, timeout time.Duration) *PublicFilterAPI {
+// NewPublicFilterAPI returns a new PublicFilterAPI instance. Active subscriptions are tracked
+// in the given registry, a new one is created when it is nil.
+func NewPublicFilterAPI(backend Backend, timeout time.Duration, subscribers *SubscriberRegistry) *PublicFilterAPI {
+	if subscribers == nil {
+		subscribers = NewSubscriberRegistry()
+	}
 	api := &PublicFilterAPI{
-		backend: backend,
-		events:  NewEventSystem(backend),
-		timeout: timeout,
+		backend:     backend,
+		events:      NewEventSystem(backend),
+		timeout:     timeout,
+		subscribers: subscribers,
 	}
 
 	return api
@@ -108,17 +115,38 @@ func (api *PublicFilterAPI) ConfirmVanBlockHashes(
 	return res, nil
 }
 
-// MinimalConsensusInfo
-func (api *PublicFilterAPI) MinimalConsensusInfo(ctx context.Context, requestedEpoch uint64) (*rpc.Subscription, error) {
+// SyncStatus returns the connectivity of vanguard and pandora and how far the orchestrator lags behind vanguard
+func (api *PublicFilterAPI) SyncStatus(ctx context.Context) (*generalTypes.SyncStatus, error) {
+	return api.backend.SyncStatus()
+}
+
+// MinimalConsensusInfo streams consensus infos from the requested epoch. Optional options narrow down
+// the streamed epochs and project their validator lists, without them every epoch is sent in full.
+func (api *PublicFilterAPI) MinimalConsensusInfo(
+	ctx context.Context,
+	requestedEpoch uint64,
+	opts *ConsensusInfoOptions,
+) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
+	if opts != nil {
+		if err := opts.validate(requestedEpoch); err != nil {
+			return &rpc.Subscription{}, err
+		}
+	}
+	projector := newConsensusInfoProjector(opts)
+	liveOnly := opts != nil && opts.LiveOnly
 	rpcSub := notifier.CreateSubscription()
 
 	go func() {
+		defer api.subscribers.track(rpcSub.ID, minimalConsensusInfoMethod, requestedEpoch)()
 
+		// done is set once the end epoch is sent, the subscription is closed then
+		done := false
 		batchSender := func(start, end uint64) error {
+			end = projector.clamp(end)
 			epochInfos, err := api.backend.ConsensusInfoByEpochRange(start)
 			if err != nil {
 				log.WithError(err).Error("Some epoch infos are missing in db.")
@@ -126,13 +154,19 @@ func (api *PublicFilterAPI) MinimalConsensusInfo(ctx context.Context, requestedE
 			}
 			latestFinalizedSlot := api.backend.LatestFinalizedSlot()
 			for _, ei := range epochInfos {
-				if err := notifier.Notify(rpcSub.ID, &generalTypes.MinimalEpochConsensusInfoV2{
+				if projector.skip(ei.Epoch) {
+					// the epochs are ordered, the following ones are after the end epoch as well
+					done = true
+					return nil
+				}
+				if err := notifier.Notify(rpcSub.ID, projector.project(&generalTypes.MinimalEpochConsensusInfoV2{
 					Epoch:            ei.Epoch,
 					ValidatorList:    ei.ValidatorList,
 					EpochStartTime:   ei.EpochStartTime,
 					SlotTimeDuration: ei.SlotTimeDuration,
 					FinalizedSlot:    latestFinalizedSlot,
-				}); err != nil {
+					Version:          ei.Version,
+				})); err != nil {
 					log.WithField("start", start).
 						WithField("end", end).
 						WithError(err).
@@ -141,17 +175,24 @@ func (api *PublicFilterAPI) MinimalConsensusInfo(ctx context.Context, requestedE
 				}
 				log.WithField("epoch", ei.Epoch).WithField("latestFinalizedSlot", latestFinalizedSlot).
 					Info("published epoch info to pandora")
+				if done = projector.done(ei.Epoch); done {
+					return nil
+				}
 			}
 			return nil
 		}
 
 		startEpoch := requestedEpoch
 		endEpoch := api.backend.LatestEpoch()
-		if startEpoch <= endEpoch {
+		if !liveOnly && startEpoch <= endEpoch {
 			log.WithField("startEpoch", startEpoch).WithField("endEpoch", endEpoch).Debug("Sending previous epoch infos to pandora")
 			if err := batchSender(startEpoch, endEpoch); err != nil {
 				return
 			}
+			if done {
+				log.WithField("toEpoch", *opts.ToEpoch).Info("Sent the end epoch, closing consensus info subscription")
+				return
+			}
 		}
 
 		consensusInfo := make(chan *generalTypes.MinimalEpochConsensusInfoV2)
@@ -161,6 +202,11 @@ func (api *PublicFilterAPI) MinimalConsensusInfo(ctx context.Context, requestedE
 		for {
 			select {
 			case currentEpochInfo := <-consensusInfo:
+				if projector.skip(currentEpochInfo.Epoch) {
+					log.WithField("toEpoch", *opts.ToEpoch).Info("Passed the end epoch, closing consensus info subscription")
+					consensusInfoSub.Unsubscribe()
+					return
+				}
 				log.WithField("epoch", currentEpochInfo.Epoch).
 					WithField("epochStartTime", currentEpochInfo.EpochStartTime).
 					Info("Sending consensus info to subscriber")
@@ -170,24 +216,32 @@ func (api *PublicFilterAPI) MinimalConsensusInfo(ctx context.Context, requestedE
 					startEpoch = endEpoch
 					endEpoch = api.backend.LatestEpoch()
 
-					if startEpoch+1 < endEpoch {
+					if !liveOnly && startEpoch+1 < endEpoch {
 						log.WithField("startEpoch", startEpoch).WithField("endEpoch", endEpoch).
 							Debug("successfully published left over epoch infos")
 						if err := batchSender(startEpoch, endEpoch); err != nil {
 							return
 						}
+						if done {
+							log.WithField("toEpoch", *opts.ToEpoch).
+								Info("Sent the end epoch, closing consensus info subscription")
+							consensusInfoSub.Unsubscribe()
+							return
+						}
 					}
 					log.WithField("liveSyncEpoch", endEpoch+1).Debug("start publishing live epoch info to pandora")
 				}
 
-				err := notifier.Notify(rpcSub.ID, &generalTypes.MinimalEpochConsensusInfoV2{
+				err := notifier.Notify(rpcSub.ID, projector.project(&generalTypes.MinimalEpochConsensusInfoV2{
 					Epoch:            currentEpochInfo.Epoch,
 					ValidatorList:    currentEpochInfo.ValidatorList,
 					EpochStartTime:   currentEpochInfo.EpochStartTime,
 					SlotTimeDuration: currentEpochInfo.SlotTimeDuration,
 					ReorgInfo:        currentEpochInfo.ReorgInfo,
 					FinalizedSlot:    currentEpochInfo.FinalizedSlot,
-				})
+					Version:          currentEpochInfo.Version,
+					Replaced:         currentEpochInfo.Replaced,
+				}))
 				if nil != err {
 					log.WithField("epoch", currentEpochInfo.Epoch).WithError(err).Error(
 						"Failed to notify consensus info")
@@ -196,6 +250,11 @@ func (api *PublicFilterAPI) MinimalConsensusInfo(ctx context.Context, requestedE
 
 				log.WithField("epoch", currentEpochInfo.Epoch).WithField("latestFinalizedSlot", currentEpochInfo.FinalizedSlot).
 					Info("published epoch info to pandora")
+				if projector.done(currentEpochInfo.Epoch) {
+					log.WithField("toEpoch", *opts.ToEpoch).Info("Sent the end epoch, closing consensus info subscription")
+					consensusInfoSub.Unsubscribe()
+					return
+				}
 
 			case <-rpcSub.Err():
 				log.Info("Unsubscribing registered pandora client")
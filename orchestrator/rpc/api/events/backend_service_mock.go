@@ -20,6 +20,7 @@ type MockBackend struct {
 	ConsensusInfos    []*eventTypes.MinimalEpochConsensusInfoV2
 	verifiedSlotInfos map[uint64]*eventTypes.SlotInfo
 	CurEpoch          uint64
+	CurSyncStatus     *eventTypes.SyncStatus
 }
 
 var _ Backend = &MockBackend{}
@@ -27,7 +28,9 @@ var _ Backend = &MockBackend{}
 func (b *MockBackend) ConsensusInfoByEpochRange(fromEpoch uint64) ([]*eventTypes.MinimalEpochConsensusInfoV2, error) {
 	consensusInfos := make([]*eventTypes.MinimalEpochConsensusInfoV2, 0)
 	for _, consensusInfo := range b.ConsensusInfos {
-		consensusInfos = append(consensusInfos, consensusInfo)
+		if consensusInfo.Epoch >= fromEpoch {
+			consensusInfos = append(consensusInfos, consensusInfo)
+		}
 	}
 	return consensusInfos, nil
 }
@@ -67,3 +70,7 @@ func (mb *MockBackend) LatestVerifiedSlot() uint64 {
 func (mb *MockBackend) LatestFinalizedSlot() uint64 {
 	return 100
 }
+
+func (mb *MockBackend) SyncStatus() (*eventTypes.SyncStatus, error) {
+	return mb.CurSyncStatus, nil
+}
@@ -0,0 +1,131 @@
+package events
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	generalTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// validator list projections of the consensus info subscription
+const (
+	ValidatorListFull = "full" // every consensus info carries its full validator list
+	ValidatorListOmit = "omit" // consensus infos are sent without validator list
+	ValidatorListDiff = "diff" // the first consensus info carries its full list, the following ones the changed slots only
+)
+
+var (
+	errInvalidEpochRange          = errors.New("end epoch is lower than the requested epoch")
+	errInvalidValidatorList       = errors.New("unknown validator list projection")
+	errInvalidValidatorOfInterest = errors.New("malformed validator public key")
+)
+
+// ConsensusInfoOptions narrow down the consensus infos streamed by a MinimalConsensusInfo subscription.
+type ConsensusInfoOptions struct {
+	ToEpoch       *uint64  `json:"toEpoch"`       // the subscription ends once it is sent
+	LiveOnly      bool     `json:"liveOnly"`      // stored epochs are not streamed, only new ones
+	ValidatorList string   `json:"validatorList"` // full (default), omit or diff
+	Validators    []string `json:"validators"`    // public keys of interest, only the slots they propose are sent
+}
+
+// ProjectedConsensusInfo is a consensus info projected according to the subscription options.
+type ProjectedConsensusInfo struct {
+	*generalTypes.MinimalEpochConsensusInfoV2
+	// validator of every slot whose validator changed since the previously sent epoch, by position in the epoch
+	ValidatorChanges map[uint64]string `json:"validatorChanges,omitempty"`
+	// validator of interest proposing every of their slots of the epoch, by slot
+	Proposals map[uint64]string `json:"proposals,omitempty"`
+}
+
+// validate checks the options of a subscription starting at the given epoch.
+func (opts *ConsensusInfoOptions) validate(requestedEpoch uint64) error {
+	if opts.ToEpoch != nil && *opts.ToEpoch < requestedEpoch {
+		return errors.Wrapf(errInvalidEpochRange, "requested epoch %d, end epoch %d", requestedEpoch, *opts.ToEpoch)
+	}
+	switch opts.ValidatorList {
+	case "", ValidatorListFull, ValidatorListOmit, ValidatorListDiff:
+	default:
+		return errors.Wrapf(errInvalidValidatorList, "%q", opts.ValidatorList)
+	}
+	for _, pubKey := range opts.Validators {
+		if decoded, err := hexutil.Decode(pubKey); err != nil || len(decoded) != generalTypes.BLSPubKeySize {
+			return errors.Wrapf(errInvalidValidatorOfInterest, "%q", pubKey)
+		}
+	}
+	return nil
+}
+
+// consensusInfoProjector applies the options of a subscription to the consensus infos it streams.
+type consensusInfoProjector struct {
+	opts           *ConsensusInfoOptions
+	validators     map[string]struct{} // lower case public keys of interest
+	lastValidators []string            // validator list of the previously sent epoch, diff only
+}
+
+func newConsensusInfoProjector(opts *ConsensusInfoOptions) *consensusInfoProjector {
+	if opts == nil {
+		opts = &ConsensusInfoOptions{}
+	}
+	p := &consensusInfoProjector{opts: opts}
+	if len(opts.Validators) > 0 {
+		p.validators = make(map[string]struct{}, len(opts.Validators))
+		for _, pubKey := range opts.Validators {
+			p.validators[strings.ToLower(pubKey)] = struct{}{}
+		}
+	}
+	return p
+}
+
+// skip returns true when the given epoch is after the end epoch.
+func (p *consensusInfoProjector) skip(epoch uint64) bool {
+	return p.opts.ToEpoch != nil && epoch > *p.opts.ToEpoch
+}
+
+// clamp returns the given end of a batch, lowered to the end epoch.
+func (p *consensusInfoProjector) clamp(end uint64) uint64 {
+	if p.opts.ToEpoch != nil && end > *p.opts.ToEpoch {
+		return *p.opts.ToEpoch
+	}
+	return end
+}
+
+// done returns true once the given sent epoch is the end epoch, the subscription has nothing left to stream.
+func (p *consensusInfoProjector) done(epoch uint64) bool {
+	return p.opts.ToEpoch != nil && epoch >= *p.opts.ToEpoch
+}
+
+// project returns the notification of a consensus info. Without projection, the consensus info is sent as is.
+func (p *consensusInfoProjector) project(info *generalTypes.MinimalEpochConsensusInfoV2) interface{} {
+	if p.validators == nil && (p.opts.ValidatorList == "" || p.opts.ValidatorList == ValidatorListFull) {
+		return info
+	}
+
+	projectedInfo := *info
+	projectedInfo.ValidatorList = nil
+	projected := &ProjectedConsensusInfo{MinimalEpochConsensusInfoV2: &projectedInfo}
+	switch {
+	case p.validators != nil:
+		projected.Proposals = make(map[uint64]string)
+		slotsPerEpoch := uint64(len(info.ValidatorList))
+		for i, pubKey := range info.ValidatorList {
+			if _, ok := p.validators[strings.ToLower(pubKey)]; ok {
+				projected.Proposals[info.Epoch*slotsPerEpoch+uint64(i)] = pubKey
+			}
+		}
+	case p.opts.ValidatorList == ValidatorListDiff:
+		if len(p.lastValidators) != len(info.ValidatorList) {
+			// the first list, or a list of another length, is sent in full
+			projectedInfo.ValidatorList = info.ValidatorList
+		} else {
+			projected.ValidatorChanges = make(map[uint64]string)
+			for i, pubKey := range info.ValidatorList {
+				if p.lastValidators[i] != pubKey {
+					projected.ValidatorChanges[uint64(i)] = pubKey
+				}
+			}
+		}
+		p.lastValidators = info.ValidatorList
+	}
+	return projected
+}
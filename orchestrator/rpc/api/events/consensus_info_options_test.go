@@ -0,0 +1,152 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	generalTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func pubKey(b byte) string {
+	key := make([]byte, generalTypes.BLSPubKeySize)
+	key[0] = b
+	return hexutil.Encode(key)
+}
+
+func TestConsensusInfoOptions_Validate(t *testing.T) {
+	toEpoch := uint64(3)
+	assert.NoError(t, (&ConsensusInfoOptions{ToEpoch: &toEpoch, ValidatorList: ValidatorListDiff}).validate(3))
+	assert.ErrorContains(t, errInvalidEpochRange.Error(), (&ConsensusInfoOptions{ToEpoch: &toEpoch}).validate(4))
+	assert.ErrorContains(t, errInvalidValidatorList.Error(), (&ConsensusInfoOptions{ValidatorList: "some"}).validate(0))
+	assert.ErrorContains(t, errInvalidValidatorOfInterest.Error(), (&ConsensusInfoOptions{Validators: []string{"0x01"}}).validate(0))
+	assert.NoError(t, (&ConsensusInfoOptions{Validators: []string{pubKey(1)}}).validate(0))
+}
+
+func TestConsensusInfoProjector_Full(t *testing.T) {
+	info := testutil.NewMinimalConsensusInfo(2)
+	toEpoch := uint64(2)
+	projector := newConsensusInfoProjector(&ConsensusInfoOptions{ToEpoch: &toEpoch})
+
+	assert.Equal(t, false, projector.skip(2))
+	assert.Equal(t, true, projector.skip(3))
+	assert.DeepEqual(t, info, projector.project(info))
+	assert.DeepEqual(t, info, newConsensusInfoProjector(nil).project(info))
+}
+
+func TestConsensusInfoProjector_Omit(t *testing.T) {
+	info := testutil.NewMinimalConsensusInfo(2)
+	projected, ok := newConsensusInfoProjector(&ConsensusInfoOptions{ValidatorList: ValidatorListOmit}).
+		project(info).(*ProjectedConsensusInfo)
+	require.Equal(t, true, ok)
+
+	assert.Equal(t, 0, len(projected.ValidatorList))
+	assert.Equal(t, info.Epoch, projected.Epoch)
+	// the original consensus info is left untouched
+	assert.Equal(t, 32, len(info.ValidatorList))
+}
+
+func TestConsensusInfoProjector_Diff(t *testing.T) {
+	projector := newConsensusInfoProjector(&ConsensusInfoOptions{ValidatorList: ValidatorListDiff})
+
+	first := projector.project(testutil.NewMinimalConsensusInfo(1)).(*ProjectedConsensusInfo)
+	assert.Equal(t, 32, len(first.ValidatorList))
+	assert.Equal(t, 0, len(first.ValidatorChanges))
+
+	info := testutil.NewMinimalConsensusInfo(2)
+	info.ValidatorList[3] = pubKey(3)
+	second := projector.project(info).(*ProjectedConsensusInfo)
+	assert.Equal(t, 0, len(second.ValidatorList))
+	assert.DeepEqual(t, map[uint64]string{3: pubKey(3)}, second.ValidatorChanges)
+
+	third := projector.project(testutil.NewMinimalConsensusInfo(3)).(*ProjectedConsensusInfo)
+	assert.DeepEqual(t, map[uint64]string{3: testutil.NewMinimalConsensusInfo(3).ValidatorList[3]}, third.ValidatorChanges)
+}
+
+func TestConsensusInfoProjector_Validators(t *testing.T) {
+	info := testutil.NewMinimalConsensusInfo(2)
+	info.ValidatorList[1] = pubKey(0xab)
+	info.ValidatorList[30] = pubKey(0xab)
+	info.ValidatorList[5] = pubKey(5)
+
+	projector := newConsensusInfoProjector(&ConsensusInfoOptions{Validators: []string{pubKey(0xAB)}})
+	projected := projector.project(info).(*ProjectedConsensusInfo)
+
+	assert.Equal(t, 0, len(projected.ValidatorList))
+	assert.DeepEqual(t, map[uint64]string{65: pubKey(0xab), 94: pubKey(0xab)}, projected.Proposals)
+}
+
+// subscribeConsensusInfo subscribes to the consensus infos of the given api from the given epoch over rpc.
+func subscribeConsensusInfo(
+	t *testing.T,
+	eventApi *PublicFilterAPI,
+	fromEpoch uint64,
+	opts *ConsensusInfoOptions,
+) chan *generalTypes.MinimalEpochConsensusInfoV2 {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("orc", eventApi))
+	client := rpc.DialInProc(server)
+	t.Cleanup(client.Close)
+
+	infoCh := make(chan *generalTypes.MinimalEpochConsensusInfoV2, 8)
+	sub, err := client.Subscribe(context.Background(), "orc", infoCh, "minimalConsensusInfo", fromEpoch, opts)
+	require.NoError(t, err)
+	t.Cleanup(sub.Unsubscribe)
+	return infoCh
+}
+
+// waitClosed waits until the subscriptions of the given api are closed.
+func waitClosed(t *testing.T, eventApi *PublicFilterAPI, tick func()) {
+	for deadline := time.Now().Add(5 * time.Second); len(eventApi.subscribers.List()) > 0; {
+		require.Equal(t, true, time.Now().Before(deadline), "subscription not closed")
+		if tick != nil {
+			tick()
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMinimalConsensusInfo_EndEpochStored(t *testing.T) {
+	_, eventApi := setup(t)
+	toEpoch := uint64(2)
+	infoCh := subscribeConsensusInfo(t, eventApi, 1, &ConsensusInfoOptions{ToEpoch: &toEpoch})
+
+	for _, epoch := range []uint64{1, 2} {
+		select {
+		case info := <-infoCh:
+			assert.Equal(t, epoch, info.Epoch)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("epoch %d not received", epoch)
+		}
+	}
+	// the stream ends with the end epoch instead of waiting for new epochs
+	waitClosed(t, eventApi, nil)
+	assert.Equal(t, 0, len(infoCh))
+}
+
+func TestMinimalConsensusInfo_EndEpochLive(t *testing.T) {
+	backend, eventApi := setup(t)
+	toEpoch := uint64(5)
+	infoCh := subscribeConsensusInfo(t, eventApi, 5, &ConsensusInfoOptions{ToEpoch: &toEpoch})
+	for len(eventApi.subscribers.List()) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the epoch is dropped until the live subscription is installed, it closes the stream once sent
+	waitClosed(t, eventApi, func() {
+		backend.ConsensusInfoFeed.Send(testutil.NewMinimalConsensusInfo(5))
+	})
+	select {
+	case info := <-infoCh:
+		assert.Equal(t, uint64(5), info.Epoch)
+	case <-time.After(5 * time.Second):
+		t.Fatal("end epoch not received")
+	}
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 0, len(infoCh))
+}
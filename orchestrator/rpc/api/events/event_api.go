@@ -21,6 +21,7 @@ func (api *PublicFilterAPI) SteamConfirmedPanBlockHashes(
 	rpcSub := notifier.CreateSubscription()
 
 	go func() {
+		defer api.subscribers.track(rpcSub.ID, steamConfirmedPanBlockHashesMethod, request.Slot)()
 
 		batchSender := func(start, end uint64) error {
 			slotInfos := api.backend.VerifiedSlotInfos(start)
@@ -32,10 +33,15 @@ func (api *PublicFilterAPI) SteamConfirmedPanBlockHashes(
 					continue
 				}
 				log.WithField("hash", slotInfos[i].PandoraHeaderHash).Debug("sending verifiedInfo to pandora batchsender")
+				finalizedSlot := api.backend.LatestFinalizedSlot()
+				status := generalTypes.Verified
+				if i <= finalizedSlot {
+					status = generalTypes.Finalized
+				}
 				sendingInfo := &generalTypes.BlockStatus{
 					Hash:          slotInfos[i].PandoraHeaderHash,
-					Status:        generalTypes.Verified,
-					FinalizedSlot: api.backend.LatestFinalizedSlot(),
+					Status:        status,
+					FinalizedSlot: finalizedSlot,
 				}
 				log.WithField("info", *sendingInfo).Debug("Sending pendingness status to pandora")
 				if err := notifier.Notify(rpcSub.ID, sendingInfo); err != nil {
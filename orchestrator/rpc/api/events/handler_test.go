@@ -20,7 +20,7 @@ func setup(t *testing.T) (*MockBackend, *PublicFilterAPI) {
 		CurEpoch:       4,
 	}
 
-	eventApi := NewPublicFilterAPI(backend, deadline)
+	eventApi := NewPublicFilterAPI(backend, deadline, nil)
 	return backend, eventApi
 }
 
@@ -0,0 +1,16 @@
+package events
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	minimalConsensusInfoMethod         = "minimalConsensusInfo"
+	steamConfirmedPanBlockHashesMethod = "steamConfirmedPanBlockHashes"
+)
+
+var activeSubscriptions = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "orchestrator_rpc_active_subscriptions",
+	Help: "Number of active RPC subscriptions, partitioned by subscription method",
+}, []string{"method"})
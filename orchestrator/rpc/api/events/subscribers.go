@@ -0,0 +1,66 @@
+package events
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Subscriber describes an active RPC subscription
+type Subscriber struct {
+	ID     rpc.ID    `json:"id"`
+	Method string    `json:"method"`
+	From   uint64    `json:"from"`
+	Since  time.Time `json:"since"`
+}
+
+// SubscriberRegistry keeps track of the active RPC subscriptions
+type SubscriberRegistry struct {
+	lock        sync.RWMutex
+	subscribers map[rpc.ID]*Subscriber
+}
+
+// NewSubscriberRegistry creates an empty subscriber registry
+func NewSubscriberRegistry() *SubscriberRegistry {
+	return &SubscriberRegistry{
+		subscribers: make(map[rpc.ID]*Subscriber),
+	}
+}
+
+// track registers a new subscription and returns a function which removes it again.
+func (r *SubscriberRegistry) track(id rpc.ID, method string, from uint64) func() {
+	r.lock.Lock()
+	r.subscribers[id] = &Subscriber{
+		ID:     id,
+		Method: method,
+		From:   from,
+		Since:  time.Now(),
+	}
+	r.lock.Unlock()
+	activeSubscriptions.WithLabelValues(method).Inc()
+
+	return func() {
+		r.lock.Lock()
+		delete(r.subscribers, id)
+		r.lock.Unlock()
+		activeSubscriptions.WithLabelValues(method).Dec()
+	}
+}
+
+// List returns a copy of the active subscriptions ordered by their starting time.
+func (r *SubscriberRegistry) List() []*Subscriber {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	subscribers := make([]*Subscriber, 0, len(r.subscribers))
+	for _, subscriber := range r.subscribers {
+		cpy := *subscriber
+		subscribers = append(subscribers, &cpy)
+	}
+	sort.Slice(subscribers, func(i, j int) bool {
+		return subscribers[i].Since.Before(subscribers[j].Since)
+	})
+	return subscribers
+}
@@ -0,0 +1,32 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSubscriberRegistry(t *testing.T) {
+	registry := NewSubscriberRegistry()
+	gauge := activeSubscriptions.WithLabelValues(minimalConsensusInfoMethod)
+	initial := promtestutil.ToFloat64(gauge)
+
+	untrackFirst := registry.track("0x1", minimalConsensusInfoMethod, 3)
+	untrackSecond := registry.track("0x2", steamConfirmedPanBlockHashesMethod, 64)
+
+	subscribers := registry.List()
+	assert.Equal(t, 2, len(subscribers))
+	assert.Equal(t, minimalConsensusInfoMethod, subscribers[0].Method)
+	assert.Equal(t, uint64(3), subscribers[0].From)
+	assert.Equal(t, initial+1, promtestutil.ToFloat64(gauge))
+
+	untrackFirst()
+	subscribers = registry.List()
+	assert.Equal(t, 1, len(subscribers))
+	assert.Equal(t, steamConfirmedPanBlockHashesMethod, subscribers[0].Method)
+	assert.Equal(t, initial, promtestutil.ToFloat64(gauge))
+
+	untrackSecond()
+	assert.Equal(t, 0, len(registry.List()))
+}
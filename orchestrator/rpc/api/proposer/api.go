@@ -0,0 +1,138 @@
+package proposer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// maxDutiesEpochRange bounds the number of epochs scanned by a single proposer duties request.
+const maxDutiesEpochRange = 1024
+
+var (
+	errEpochNotFound     = errors.New("consensus info of the epoch not found")
+	errInvalidEpochRange = errors.New("invalid epoch range")
+	errInvalidPubKey     = errors.New("malformed validator public key")
+	errInvalidEpochInfo  = errors.New("stored validator list does not match the slots of the epoch")
+)
+
+// ProposerDuty is the slot of an epoch along with its proposer and start time.
+type ProposerDuty struct {
+	Slot      uint64 `json:"slot"`
+	Epoch     uint64 `json:"epoch"`
+	PubKey    string `json:"pubKey"`
+	StartTime uint64 `json:"startTime"` // unix time in seconds
+}
+
+// EpochInfo is a stored epoch along with the proposer and start time of each of its slots.
+type EpochInfo struct {
+	Epoch            uint64          `json:"epoch"`
+	EpochStartTime   uint64          `json:"epochTimeStart"`
+	SlotTimeDuration uint64          `json:"slotTimeDuration"` // in seconds
+	Version          uint64          `json:"version"`
+	Slots            []*ProposerDuty `json:"slots"`
+}
+
+// PublicProposerAPI looks up the proposers of the stored epochs.
+type PublicProposerAPI struct {
+	consensusInfoDB db.ROnlyConsensusInfoDB
+}
+
+// NewPublicProposerAPI creates a new proposer api instance
+func NewPublicProposerAPI(consensusInfoDB db.ROnlyConsensusInfoDB) *PublicProposerAPI {
+	return &PublicProposerAPI{consensusInfoDB: consensusInfoDB}
+}
+
+// GetProposer returns the proposer duty of the given slot
+func (api *PublicProposerAPI) GetProposer(ctx context.Context, slot uint64) (*ProposerDuty, error) {
+	info, err := api.consensusInfo(ctx, slot/types.SlotsPerEpoch)
+	if err != nil {
+		return nil, err
+	}
+	return duty(info, slot%types.SlotsPerEpoch), nil
+}
+
+// GetProposerDuties returns the slots proposed by the given validator from fromEpoch to toEpoch, both included.
+// Epochs which are not stored yet are left out.
+func (api *PublicProposerAPI) GetProposerDuties(
+	ctx context.Context,
+	pubKey string,
+	fromEpoch, toEpoch uint64,
+) ([]*ProposerDuty, error) {
+	if decoded, err := hexutil.Decode(pubKey); err != nil || len(decoded) != types.BLSPubKeySize {
+		return nil, errors.Wrapf(errInvalidPubKey, "%q", pubKey)
+	}
+	if toEpoch < fromEpoch || toEpoch-fromEpoch >= maxDutiesEpochRange {
+		return nil, errors.Wrapf(errInvalidEpochRange, "from epoch %d to epoch %d, at most %d epochs",
+			fromEpoch, toEpoch, maxDutiesEpochRange)
+	}
+	if latestEpoch := api.consensusInfoDB.LatestSavedEpoch(); toEpoch > latestEpoch {
+		toEpoch = latestEpoch
+	}
+
+	duties := make([]*ProposerDuty, 0)
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		info, err := api.consensusInfo(ctx, epoch)
+		if errors.Is(err, errEpochNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i, proposer := range info.ValidatorList {
+			if strings.EqualFold(proposer, pubKey) {
+				duties = append(duties, duty(info, uint64(i)))
+			}
+		}
+	}
+	return duties, nil
+}
+
+// GetEpochInfo returns the given epoch with the proposer and start time of each of its slots
+func (api *PublicProposerAPI) GetEpochInfo(ctx context.Context, epoch uint64) (*EpochInfo, error) {
+	info, err := api.consensusInfo(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	epochInfo := &EpochInfo{
+		Epoch:            info.Epoch,
+		EpochStartTime:   info.EpochStartTime,
+		SlotTimeDuration: uint64(info.SlotTimeDuration),
+		Version:          info.Version,
+		Slots:            make([]*ProposerDuty, len(info.ValidatorList)),
+	}
+	for i := range info.ValidatorList {
+		epochInfo.Slots[i] = duty(info, uint64(i))
+	}
+	return epochInfo, nil
+}
+
+// consensusInfo retrieves the stored consensus info of an epoch and checks that it holds a proposer for every slot.
+func (api *PublicProposerAPI) consensusInfo(ctx context.Context, epoch uint64) (*types.MinimalEpochConsensusInfo, error) {
+	info, err := api.consensusInfoDB.ConsensusInfo(ctx, epoch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not retrieve consensus info of epoch %d", epoch)
+	}
+	if info == nil {
+		return nil, errors.Wrapf(errEpochNotFound, "epoch %d", epoch)
+	}
+	if len(info.ValidatorList) != types.SlotsPerEpoch {
+		return nil, errors.Wrapf(errInvalidEpochInfo, "epoch %d has %d validators", epoch, len(info.ValidatorList))
+	}
+	return info, nil
+}
+
+// duty maps the slot at the given position of the epoch to its proposer and start time. Slot durations are
+// stored in seconds.
+func duty(info *types.MinimalEpochConsensusInfo, index uint64) *ProposerDuty {
+	return &ProposerDuty{
+		Slot:      info.Epoch*types.SlotsPerEpoch + index,
+		Epoch:     info.Epoch,
+		PubKey:    info.ValidatorList[index],
+		StartTime: info.EpochStartTime + index*uint64(info.SlotTimeDuration),
+	}
+}
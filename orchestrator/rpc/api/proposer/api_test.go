@@ -0,0 +1,89 @@
+package proposer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func pubKey(b byte) string {
+	key := make([]byte, types.BLSPubKeySize)
+	key[0] = b
+	return hexutil.Encode(key)
+}
+
+func setup(t *testing.T) *PublicProposerAPI {
+	ctx := context.Background()
+	db := testDB.SetupInMemoryDB(t)
+	for epoch := uint64(0); epoch < 4; epoch++ {
+		info := testutil.NewMinimalConsensusInfo(epoch)
+		info.EpochStartTime = 1000 + epoch*types.SlotsPerEpoch*6
+		info.ValidatorList[epoch] = pubKey(0xaa)
+		info.ValidatorList[31] = pubKey(0xaa)
+		require.NoError(t, db.SaveConsensusInfo(ctx, info.ConvertToEpochInfo()))
+	}
+	require.NoError(t, db.SaveLatestEpoch(ctx, 3))
+	return NewPublicProposerAPI(db)
+}
+
+func TestPublicProposerAPI_GetProposer(t *testing.T) {
+	api := setup(t)
+
+	duty, err := api.GetProposer(context.Background(), 66)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &ProposerDuty{Slot: 66, Epoch: 2, PubKey: pubKey(0xaa), StartTime: 1000 + 66*6}, duty)
+
+	duty, err = api.GetProposer(context.Background(), 67)
+	require.NoError(t, err)
+	assert.Equal(t, pubKey(0), duty.PubKey)
+
+	_, err = api.GetProposer(context.Background(), 4*types.SlotsPerEpoch)
+	assert.ErrorContains(t, errEpochNotFound.Error(), err)
+}
+
+func TestPublicProposerAPI_GetProposerDuties(t *testing.T) {
+	api := setup(t)
+
+	duties, err := api.GetProposerDuties(context.Background(), pubKey(0xaa), 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 6, len(duties))
+	assert.Equal(t, uint64(33), duties[0].Slot)
+	assert.Equal(t, uint64(63), duties[1].Slot)
+	assert.Equal(t, uint64(127), duties[5].Slot)
+	assert.Equal(t, uint64(1000+127*6), duties[5].StartTime)
+
+	duties, err = api.GetProposerDuties(context.Background(), pubKey(0xbb), 0, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(duties))
+
+	_, err = api.GetProposerDuties(context.Background(), "0xaa", 0, 3)
+	assert.ErrorContains(t, errInvalidPubKey.Error(), err)
+	_, err = api.GetProposerDuties(context.Background(), pubKey(0xaa), 3, 2)
+	assert.ErrorContains(t, errInvalidEpochRange.Error(), err)
+	_, err = api.GetProposerDuties(context.Background(), pubKey(0xaa), 0, maxDutiesEpochRange)
+	assert.ErrorContains(t, errInvalidEpochRange.Error(), err)
+}
+
+func TestPublicProposerAPI_GetEpochInfo(t *testing.T) {
+	api := setup(t)
+
+	epochInfo, err := api.GetEpochInfo(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), epochInfo.Epoch)
+	assert.Equal(t, uint64(6), epochInfo.SlotTimeDuration)
+	require.Equal(t, types.SlotsPerEpoch, len(epochInfo.Slots))
+	for i, slot := range epochInfo.Slots {
+		assert.Equal(t, uint64(32+i), slot.Slot)
+		assert.Equal(t, epochInfo.EpochStartTime+uint64(i)*6, slot.StartTime)
+	}
+	assert.Equal(t, pubKey(0xaa), epochInfo.Slots[1].PubKey)
+
+	_, err = api.GetEpochInfo(context.Background(), 5)
+	assert.ErrorContains(t, errEpochNotFound.Error(), err)
+}
@@ -0,0 +1,262 @@
+package slots
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultPageLimit is the number of slot infos of a page when the caller does not give a limit.
+	defaultPageLimit = 100
+	// maxPageLimit bounds the number of slot infos of a page.
+	maxPageLimit = 1000
+	// maxSlotRange bounds the number of slots scanned by a single slot infos request.
+	maxSlotRange = 8192
+)
+
+// sides a pending slot waits on
+const (
+	WaitingOnVanguard     = "vanguard"     // only the pandora header arrived
+	WaitingOnPandora      = "pandora"      // only the vanguard sharding info arrived
+	WaitingOnVerification = "verification" // both arrived, the slot is not verified yet
+)
+
+var (
+	errNotAvailable     = errors.New("not available on this node")
+	errInvalidSlotRange = errors.New("invalid slot range")
+	errInvalidPageLimit = errors.New("invalid page limit")
+)
+
+// Config holds the databases and caches the slot api reads from
+type Config struct {
+	VerifiedSlotInfoDB           db.ROnlyVerifiedSlotInfoDB
+	InvalidSlotInfoDB            db.ROnlyInvalidSlotInfoDB
+	VanguardPendingShardingCache cache.VanguardShardCache
+	PandoraPendingHeaderCache    cache.PandoraHeaderCache
+}
+
+// SlotInfo is the status of a slot along with the vanguard block and pandora header hashes known for it.
+type SlotInfo struct {
+	Slot              uint64       `json:"slot"`
+	Status            types.Status `json:"status"`
+	VanguardBlockHash *common.Hash `json:"vanguardBlockHash,omitempty"`
+	PandoraHeaderHash *common.Hash `json:"pandoraHeaderHash,omitempty"`
+}
+
+// SlotInfosPage is a page of slot infos. Next is the slot to request the following page from, it is
+// left out on the last page.
+type SlotInfosPage struct {
+	SlotInfos []*SlotInfo `json:"slotInfos"`
+	Next      *uint64     `json:"next,omitempty"`
+}
+
+// FinalizedInfo is the latest finalized slot and epoch.
+type FinalizedInfo struct {
+	*SlotInfo
+	Epoch uint64 `json:"epoch"`
+}
+
+// PendingSlot is a slot which is not verified yet along with the side it waits on.
+type PendingSlot struct {
+	Slot              uint64       `json:"slot"`
+	WaitingOn         string       `json:"waitingOn"`
+	VanguardBlockHash *common.Hash `json:"vanguardBlockHash,omitempty"`
+	PandoraHeaderHash *common.Hash `json:"pandoraHeaderHash,omitempty"`
+}
+
+// PublicSlotAPI offers read access to the verified, invalid and pending slots of the orchestrator.
+type PublicSlotAPI struct {
+	cfg *Config
+}
+
+// NewPublicSlotAPI creates a new slot api instance
+func NewPublicSlotAPI(cfg *Config) *PublicSlotAPI {
+	return &PublicSlotAPI{cfg: cfg}
+}
+
+// GetSlotInfo returns the status and hashes of the given slot
+func (api *PublicSlotAPI) GetSlotInfo(ctx context.Context, slot uint64) (*SlotInfo, error) {
+	return api.slotInfo(ctx, slot)
+}
+
+// GetSlotInfos returns the verified, invalid and pending slots from fromSlot to toSlot, both included.
+// Skipped slots are left out. At most limit slot infos are returned per page.
+func (api *PublicSlotAPI) GetSlotInfos(
+	ctx context.Context,
+	fromSlot, toSlot uint64,
+	limit *uint64,
+) (*SlotInfosPage, error) {
+	if toSlot < fromSlot || toSlot-fromSlot >= maxSlotRange {
+		return nil, errors.Wrapf(errInvalidSlotRange, "from slot %d to slot %d, at most %d slots",
+			fromSlot, toSlot, maxSlotRange)
+	}
+	pageLimit := uint64(defaultPageLimit)
+	if limit != nil {
+		if *limit == 0 || *limit > maxPageLimit {
+			return nil, errors.Wrapf(errInvalidPageLimit, "%d, at most %d", *limit, maxPageLimit)
+		}
+		pageLimit = *limit
+	}
+
+	page := &SlotInfosPage{SlotInfos: make([]*SlotInfo, 0)}
+	for slot := fromSlot; slot <= toSlot; slot++ {
+		if uint64(len(page.SlotInfos)) == pageLimit {
+			next := slot
+			page.Next = &next
+			break
+		}
+		slotInfo, err := api.slotInfo(ctx, slot)
+		if err != nil {
+			return nil, err
+		}
+		if slotInfo.Status == types.Skipped || slotInfo.Status == types.Unknown {
+			continue
+		}
+		page.SlotInfos = append(page.SlotInfos, slotInfo)
+	}
+	return page, nil
+}
+
+// GetSlotByPandoraHash returns the verified slot of the given pandora header hash, nil when the hash is
+// not verified
+func (api *PublicSlotAPI) GetSlotByPandoraHash(ctx context.Context, hash common.Hash) (*SlotInfo, error) {
+	slot, found, err := api.cfg.VerifiedSlotInfoDB.VerifiedSlotByPandoraHash(hash)
+	if err != nil || !found {
+		return nil, err
+	}
+	return api.slotInfo(ctx, slot)
+}
+
+// GetSlotByVanguardHash returns the verified slot of the given vanguard block hash, nil when the hash is
+// not verified
+func (api *PublicSlotAPI) GetSlotByVanguardHash(ctx context.Context, hash common.Hash) (*SlotInfo, error) {
+	slot, found, err := api.cfg.VerifiedSlotInfoDB.VerifiedSlotByVanguardHash(hash)
+	if err != nil || !found {
+		return nil, err
+	}
+	return api.slotInfo(ctx, slot)
+}
+
+// GetLatestVerified returns the latest verified slot
+func (api *PublicSlotAPI) GetLatestVerified(ctx context.Context) (*SlotInfo, error) {
+	return api.slotInfo(ctx, api.cfg.VerifiedSlotInfoDB.LatestSavedVerifiedSlot())
+}
+
+// GetFinalized returns the latest finalized slot and epoch
+func (api *PublicSlotAPI) GetFinalized(ctx context.Context) (*FinalizedInfo, error) {
+	slotInfo, err := api.slotInfo(ctx, api.cfg.VerifiedSlotInfoDB.LatestLatestFinalizedSlot())
+	if err != nil {
+		return nil, err
+	}
+	return &FinalizedInfo{
+		SlotInfo: slotInfo,
+		Epoch:    api.cfg.VerifiedSlotInfoDB.LatestLatestFinalizedEpoch(),
+	}, nil
+}
+
+// GetPendingSlots lists the slots held in the pending vanguard and pandora caches along with the side
+// each of them waits on
+func (api *PublicSlotAPI) GetPendingSlots(ctx context.Context) ([]*PendingSlot, error) {
+	if api.cfg.VanguardPendingShardingCache == nil || api.cfg.PandoraPendingHeaderCache == nil {
+		return nil, errNotAvailable
+	}
+
+	pendingSlots := make(map[uint64]*PendingSlot)
+	for _, slot := range api.cfg.VanguardPendingShardingCache.Slots() {
+		if hash := api.pendingVanguardHash(ctx, slot); hash != nil {
+			pendingSlots[slot] = &PendingSlot{Slot: slot, WaitingOn: WaitingOnPandora, VanguardBlockHash: hash}
+		}
+	}
+	for _, slot := range api.cfg.PandoraPendingHeaderCache.Slots() {
+		hash := api.pendingPandoraHash(ctx, slot)
+		if hash == nil {
+			continue
+		}
+		if pendingSlot, ok := pendingSlots[slot]; ok {
+			pendingSlot.WaitingOn = WaitingOnVerification
+			pendingSlot.PandoraHeaderHash = hash
+			continue
+		}
+		pendingSlots[slot] = &PendingSlot{Slot: slot, WaitingOn: WaitingOnVanguard, PandoraHeaderHash: hash}
+	}
+
+	sorted := make([]*PendingSlot, 0, len(pendingSlots))
+	for _, pendingSlot := range pendingSlots {
+		sorted = append(sorted, pendingSlot)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Slot < sorted[j].Slot })
+	return sorted, nil
+}
+
+// slotInfo resolves the status of a slot from the verified and invalid slot databases first, then from
+// the pending caches. Slots found nowhere are skipped up to the latest verified slot, unknown after it.
+func (api *PublicSlotAPI) slotInfo(ctx context.Context, slot uint64) (*SlotInfo, error) {
+	verifiedSlotInfo, err := api.cfg.VerifiedSlotInfoDB.VerifiedSlotInfo(slot)
+	if err != nil {
+		return nil, err
+	}
+	if verifiedSlotInfo != nil {
+		status := types.Verified
+		if slot <= api.cfg.VerifiedSlotInfoDB.LatestLatestFinalizedSlot() {
+			status = types.Finalized
+		}
+		return newSlotInfo(slot, status, verifiedSlotInfo), nil
+	}
+
+	invalidSlotInfo, err := api.cfg.InvalidSlotInfoDB.InvalidSlotInfo(slot)
+	if err != nil {
+		return nil, err
+	}
+	if invalidSlotInfo != nil {
+		return newSlotInfo(slot, types.Invalid, invalidSlotInfo), nil
+	}
+
+	slotInfo := &SlotInfo{Slot: slot, Status: types.Unknown}
+	if api.cfg.VanguardPendingShardingCache != nil && api.cfg.PandoraPendingHeaderCache != nil {
+		slotInfo.VanguardBlockHash = api.pendingVanguardHash(ctx, slot)
+		slotInfo.PandoraHeaderHash = api.pendingPandoraHash(ctx, slot)
+	}
+	switch {
+	case slotInfo.VanguardBlockHash != nil || slotInfo.PandoraHeaderHash != nil:
+		slotInfo.Status = types.Pending
+	case slot <= api.cfg.VerifiedSlotInfoDB.LatestSavedVerifiedSlot():
+		slotInfo.Status = types.Skipped
+	}
+	return slotInfo, nil
+}
+
+// pendingVanguardHash returns the block hash of the cached vanguard sharding info of a slot
+func (api *PublicSlotAPI) pendingVanguardHash(ctx context.Context, slot uint64) *common.Hash {
+	shardInfo, err := api.cfg.VanguardPendingShardingCache.Get(ctx, slot)
+	if err != nil || shardInfo == nil {
+		return nil
+	}
+	hash := common.BytesToHash(shardInfo.BlockHash)
+	return &hash
+}
+
+// pendingPandoraHash returns the hash of the cached pandora header of a slot
+func (api *PublicSlotAPI) pendingPandoraHash(ctx context.Context, slot uint64) *common.Hash {
+	header, err := api.cfg.PandoraPendingHeaderCache.Get(ctx, slot)
+	if err != nil || header == nil {
+		return nil
+	}
+	hash := header.Hash()
+	return &hash
+}
+
+func newSlotInfo(slot uint64, status types.Status, slotInfo *types.SlotInfo) *SlotInfo {
+	vanguardBlockHash, pandoraHeaderHash := slotInfo.VanguardBlockHash, slotInfo.PandoraHeaderHash
+	return &SlotInfo{
+		Slot:              slot,
+		Status:            status,
+		VanguardBlockHash: &vanguardBlockHash,
+		PandoraHeaderHash: &pandoraHeaderHash,
+	}
+}
@@ -0,0 +1,168 @@
+package slots
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func slotInfo(slot uint64) *types.SlotInfo {
+	return &types.SlotInfo{
+		VanguardBlockHash: common.BytesToHash([]byte{0xa, byte(slot)}),
+		PandoraHeaderHash: common.BytesToHash([]byte{0xb, byte(slot)}),
+	}
+}
+
+// setup stores verified slots 1 to 10 except slot 4, finalizes up to slot 6, marks slot 11 as invalid and
+// caches a vanguard sharding info for slots 12 and 13 and a pandora header for slots 13 and 14
+func setup(t *testing.T) *PublicSlotAPI {
+	ctx := context.Background()
+	db := testDB.SetupInMemoryDB(t)
+	for slot := uint64(1); slot <= 10; slot++ {
+		if slot != 4 {
+			require.NoError(t, db.SaveVerifiedSlotInfo(slot, slotInfo(slot)))
+		}
+	}
+	require.NoError(t, db.SaveLatestVerifiedSlot(ctx, 10))
+	require.NoError(t, db.SaveLatestFinalizedSlot(6))
+	require.NoError(t, db.SaveLatestFinalizedEpoch(1))
+	require.NoError(t, db.SaveInvalidSlotInfo(11, slotInfo(11)))
+
+	vanguardCache := cache.NewVanShardInfoCache(1 << 10)
+	pandoraCache := cache.NewPanHeaderCache()
+	for _, slot := range []uint64{12, 13} {
+		require.NoError(t, vanguardCache.Put(ctx, slot, &types.VanguardShardInfo{
+			Slot:      slot,
+			BlockHash: slotInfo(slot).VanguardBlockHash.Bytes(),
+		}))
+	}
+	for _, slot := range []uint64{13, 14} {
+		require.NoError(t, pandoraCache.Put(ctx, slot, testutil.NewEth1Header(slot)))
+	}
+
+	return NewPublicSlotAPI(&Config{
+		VerifiedSlotInfoDB:           db,
+		InvalidSlotInfoDB:            db,
+		VanguardPendingShardingCache: vanguardCache,
+		PandoraPendingHeaderCache:    pandoraCache,
+	})
+}
+
+func TestPublicSlotAPI_GetSlotInfo(t *testing.T) {
+	api := setup(t)
+	ctx := context.Background()
+
+	statuses := map[uint64]types.Status{
+		3:  types.Finalized,
+		4:  types.Skipped,
+		8:  types.Verified,
+		11: types.Invalid,
+		12: types.Pending,
+		14: types.Pending,
+		15: types.Unknown,
+	}
+	for slot, status := range statuses {
+		info, err := api.GetSlotInfo(ctx, slot)
+		require.NoError(t, err)
+		assert.Equal(t, status, info.Status, "slot %d", slot)
+	}
+
+	info, err := api.GetSlotInfo(ctx, 8)
+	require.NoError(t, err)
+	assert.Equal(t, slotInfo(8).PandoraHeaderHash, *info.PandoraHeaderHash)
+	assert.Equal(t, slotInfo(8).VanguardBlockHash, *info.VanguardBlockHash)
+
+	info, err = api.GetSlotInfo(ctx, 14)
+	require.NoError(t, err)
+	assert.Equal(t, testutil.NewEth1Header(14).Hash(), *info.PandoraHeaderHash)
+	assert.Equal(t, true, info.VanguardBlockHash == nil)
+}
+
+func TestPublicSlotAPI_GetSlotInfos(t *testing.T) {
+	api := setup(t)
+	ctx := context.Background()
+	limit := uint64(4)
+
+	page, err := api.GetSlotInfos(ctx, 2, 20, &limit)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(page.SlotInfos))
+	assert.Equal(t, uint64(2), page.SlotInfos[0].Slot)
+	// skipped slot 4 is left out
+	assert.Equal(t, uint64(5), page.SlotInfos[2].Slot)
+	require.NotNil(t, page.Next)
+	assert.Equal(t, uint64(7), *page.Next)
+
+	page, err = api.GetSlotInfos(ctx, *page.Next, 20, nil)
+	require.NoError(t, err)
+	require.Equal(t, 8, len(page.SlotInfos))
+	assert.Equal(t, uint64(14), page.SlotInfos[7].Slot)
+	assert.Equal(t, true, page.Next == nil)
+
+	_, err = api.GetSlotInfos(ctx, 5, 4, nil)
+	assert.ErrorContains(t, errInvalidSlotRange.Error(), err)
+	_, err = api.GetSlotInfos(ctx, 0, maxSlotRange, nil)
+	assert.ErrorContains(t, errInvalidSlotRange.Error(), err)
+	limit = maxPageLimit + 1
+	_, err = api.GetSlotInfos(ctx, 0, 10, &limit)
+	assert.ErrorContains(t, errInvalidPageLimit.Error(), err)
+}
+
+func TestPublicSlotAPI_GetSlotByHash(t *testing.T) {
+	api := setup(t)
+	ctx := context.Background()
+
+	info, err := api.GetSlotByPandoraHash(ctx, slotInfo(7).PandoraHeaderHash)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), info.Slot)
+	assert.Equal(t, types.Verified, info.Status)
+
+	info, err = api.GetSlotByVanguardHash(ctx, slotInfo(2).VanguardBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), info.Slot)
+
+	info, err = api.GetSlotByPandoraHash(ctx, slotInfo(11).PandoraHeaderHash)
+	require.NoError(t, err)
+	assert.Equal(t, true, info == nil)
+}
+
+func TestPublicSlotAPI_LatestVerifiedAndFinalized(t *testing.T) {
+	api := setup(t)
+	ctx := context.Background()
+
+	latest, err := api.GetLatestVerified(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), latest.Slot)
+	assert.Equal(t, types.Verified, latest.Status)
+
+	finalized, err := api.GetFinalized(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), finalized.Slot)
+	assert.Equal(t, uint64(1), finalized.Epoch)
+	assert.Equal(t, types.Finalized, finalized.Status)
+}
+
+func TestPublicSlotAPI_GetPendingSlots(t *testing.T) {
+	api := setup(t)
+
+	pendingSlots, err := api.GetPendingSlots(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, len(pendingSlots))
+	assert.Equal(t, uint64(12), pendingSlots[0].Slot)
+	assert.Equal(t, WaitingOnPandora, pendingSlots[0].WaitingOn)
+	assert.Equal(t, uint64(13), pendingSlots[1].Slot)
+	assert.Equal(t, WaitingOnVerification, pendingSlots[1].WaitingOn)
+	assert.Equal(t, slotInfo(13).VanguardBlockHash, *pendingSlots[1].VanguardBlockHash)
+	assert.Equal(t, testutil.NewEth1Header(13).Hash(), *pendingSlots[1].PandoraHeaderHash)
+	assert.Equal(t, uint64(14), pendingSlots[2].Slot)
+	assert.Equal(t, WaitingOnVanguard, pendingSlots[2].WaitingOn)
+
+	_, err = NewPublicSlotAPI(&Config{}).GetPendingSlots(context.Background())
+	assert.ErrorContains(t, errNotAvailable.Error(), err)
+}
@@ -3,6 +3,7 @@ package rpc
 import (
 	"compress/gzip"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -24,23 +25,37 @@ type httpConfig struct {
 	CorsAllowedOrigins []string
 	Vhosts             []string
 	prefix             string // path prefix on which to mount http handler
+	jwtSecret          []byte // optional JWT secret, authenticated requests reach the non-public modules
+	authPublic         bool   // public modules require authentication as well
 }
 
 // wsConfig is the JSON-RPC/Websocket configuration
 type wsConfig struct {
-	Origins []string
-	Modules []string
-	prefix  string // path prefix on which to mount ws handler
+	Origins    []string
+	Modules    []string
+	prefix     string // path prefix on which to mount ws handler
+	jwtSecret  []byte // optional JWT secret, authenticated requests reach the non-public modules
+	authPublic bool   // public modules require authentication as well
 }
 
 type rpcHandler struct {
 	http.Handler
-	server *rpc.Server
+	server     *rpc.Server
+	authServer *rpc.Server // serves authenticated requests, nil when jwt authentication is disabled
+}
+
+// stop stops the rpc servers of the handler.
+func (r *rpcHandler) stop() {
+	r.server.Stop()
+	if r.authServer != nil {
+		r.authServer.Stop()
+	}
 }
 
 type httpServer struct {
-	timeouts rpc.HTTPTimeouts
-	mux      http.ServeMux // registered handlers go here
+	timeouts  rpc.HTTPTimeouts
+	tlsConfig *tls.Config   // serves http and websocket over TLS when set
+	mux       http.ServeMux // registered handlers go here
 
 	mu       sync.Mutex
 	server   *http.Server
@@ -124,11 +139,18 @@ func (h *httpServer) start() error {
 		h.disableWS()
 		return err
 	}
+	if h.tlsConfig != nil {
+		listener = tls.NewListener(listener, h.tlsConfig)
+	}
 	h.listener = listener
 	go h.server.Serve(listener)
 
+	httpScheme, wsScheme := "http", "ws"
+	if h.tlsConfig != nil {
+		httpScheme, wsScheme = "https", "wss"
+	}
 	if h.wsAllowed() {
-		url := fmt.Sprintf("ws://%v", listener.Addr())
+		url := fmt.Sprintf("%s://%v", wsScheme, listener.Addr())
 		if h.wsConfig.prefix != "" {
 			url += h.wsConfig.prefix
 		}
@@ -142,7 +164,8 @@ func (h *httpServer) start() error {
 	log.WithField("endpoint", listener.Addr()).WithField(
 		"prefix", h.httpConfig.prefix).WithField(
 		"cors", strings.Join(h.httpConfig.CorsAllowedOrigins, ",")).WithField(
-		"vhosts", strings.Join(h.httpConfig.Vhosts, ",")).Info("HTTP server started")
+		"vhosts", strings.Join(h.httpConfig.Vhosts, ",")).WithField(
+		"tls", h.tlsConfig != nil).Info("HTTP server started")
 
 	// Log all handlers mounted on server.
 	var paths []string
@@ -155,7 +178,7 @@ func (h *httpServer) start() error {
 		name := h.handlerNames[path]
 		if !logged[name] {
 			log.WithField("server", name).WithField(
-				"url", "http://"+listener.Addr().String()+path).Info("listening on port")
+				"url", httpScheme+"://"+listener.Addr().String()+path).Info("listening on port")
 			logged[name] = true
 		}
 	}
@@ -233,14 +256,14 @@ func (h *httpServer) doStop() {
 
 	// Shut down the server.
 	httpHandler := h.httpHandler.Load().(*rpcHandler)
-	wsHandler := h.httpHandler.Load().(*rpcHandler)
+	wsHandler := h.wsHandler.Load().(*rpcHandler)
 	if httpHandler != nil {
 		h.httpHandler.Store((*rpcHandler)(nil))
-		httpHandler.server.Stop()
+		httpHandler.stop()
 	}
 	if wsHandler != nil {
 		h.wsHandler.Store((*rpcHandler)(nil))
-		wsHandler.server.Stop()
+		wsHandler.stop()
 	}
 	h.server.Shutdown(context.Background())
 	h.listener.Close()
@@ -260,16 +283,18 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 		return fmt.Errorf("JSON-RPC over HTTP is already enabled")
 	}
 
-	// Create RPC server and handler.
-	srv := rpc.NewServer()
-	if err := RegisterApisFromWhitelist(apis, config.Modules, srv, false); err != nil {
+	// Create RPC servers and handler.
+	handler, err := newRPCHandler(apis, config.Modules, config.jwtSecret, config.authPublic)
+	if err != nil {
 		return err
 	}
+	var next http.Handler = handler.server
+	if handler.authServer != nil {
+		next = newJWTHandler(config.jwtSecret, handler.authServer, fallbackHandler(handler.server, config.authPublic))
+	}
+	handler.Handler = NewHTTPHandlerStack(next, config.CorsAllowedOrigins, config.Vhosts)
 	h.httpConfig = config
-	h.httpHandler.Store(&rpcHandler{
-		Handler: NewHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts),
-		server:  srv,
-	})
+	h.httpHandler.Store(handler)
 	return nil
 }
 
@@ -278,7 +303,7 @@ func (h *httpServer) disableRPC() bool {
 	handler := h.httpHandler.Load().(*rpcHandler)
 	if handler != nil {
 		h.httpHandler.Store((*rpcHandler)(nil))
-		handler.server.Stop()
+		handler.stop()
 	}
 	return handler != nil
 }
@@ -292,16 +317,18 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 		return fmt.Errorf("JSON-RPC over WebSocket is already enabled")
 	}
 
-	// Create RPC server and handler.
-	srv := rpc.NewServer()
-	if err := RegisterApisFromWhitelist(apis, config.Modules, srv, false); err != nil {
+	// Create RPC servers and handler.
+	handler, err := newRPCHandler(apis, config.Modules, config.jwtSecret, config.authPublic)
+	if err != nil {
 		return err
 	}
+	handler.Handler = handler.server.WebsocketHandler(config.Origins)
+	if handler.authServer != nil {
+		handler.Handler = newJWTHandler(config.jwtSecret, handler.authServer.WebsocketHandler(config.Origins),
+			fallbackHandler(handler.Handler, config.authPublic))
+	}
 	h.wsConfig = config
-	h.wsHandler.Store(&rpcHandler{
-		Handler: srv.WebsocketHandler(config.Origins),
-		server:  srv,
-	})
+	h.wsHandler.Store(handler)
 	return nil
 }
 
@@ -322,7 +349,7 @@ func (h *httpServer) disableWS() bool {
 	ws := h.wsHandler.Load().(*rpcHandler)
 	if ws != nil {
 		h.wsHandler.Store((*rpcHandler)(nil))
-		ws.server.Stop()
+		ws.stop()
 	}
 	return ws != nil
 }
@@ -500,8 +527,34 @@ func (is *ipcServer) stop() error {
 // and then registers all of the APIs exposed by the services.
 func RegisterApisFromWhitelist(apis []rpc.API, modules []string, srv *rpc.Server, exposeAll bool) error {
 	if bad, available := checkModuleAvailability(modules, apis); len(bad) > 0 {
-		log.Error("Unavailable modules in HTTP API list", "unavailable", bad, "available", available)
+		log.WithField("unavailable", bad).WithField("available", available).Error("Unavailable modules in HTTP API list")
+	}
+	return registerApis(apis, modules, srv, exposeAll)
+}
+
+// newRPCHandler creates the rpc servers of a handler. The unauthenticated server only serves the whitelisted
+// apis which don't require authentication, the authenticated server is only created when a jwt secret is set.
+func newRPCHandler(apis []rpc.API, modules []string, jwtSecret []byte, authPublic bool) (*rpcHandler, error) {
+	if bad, available := checkModuleAvailability(modules, apis); len(bad) > 0 {
+		log.WithField("unavailable", bad).WithField("available", available).Error("Unavailable modules in HTTP API list")
+	}
+	handler := &rpcHandler{server: rpc.NewServer()}
+	if err := registerApis(unauthenticatedAPIs(apis, authPublic), modules, handler.server, false); err != nil {
+		return nil, err
 	}
+	if len(jwtSecret) == 0 {
+		return handler, nil
+	}
+	handler.authServer = rpc.NewServer()
+	if err := registerApis(apis, modules, handler.authServer, false); err != nil {
+		handler.server.Stop()
+		return nil, err
+	}
+	return handler, nil
+}
+
+// registerApis registers the whitelisted apis on the given server.
+func registerApis(apis []rpc.API, modules []string, srv *rpc.Server, exposeAll bool) error {
 	// Generate the whitelist based on the allowed modules
 	whitelist := make(map[string]bool)
 	for _, module := range modules {
@@ -518,6 +571,30 @@ func RegisterApisFromWhitelist(apis []rpc.API, modules []string, srv *rpc.Server
 	return nil
 }
 
+// unauthenticatedAPIs returns the APIs which are served without jwt authentication. Non-public APIs
+// always require authentication, public APIs only when authPublic is set.
+func unauthenticatedAPIs(apis []rpc.API, authPublic bool) []rpc.API {
+	if authPublic {
+		return nil
+	}
+	var filtered []rpc.API
+	for _, api := range apis {
+		if api.Public {
+			filtered = append(filtered, api)
+		}
+	}
+	return filtered
+}
+
+// fallbackHandler returns the handler serving requests without a jwt token, nil when every request
+// must be authenticated.
+func fallbackHandler(unauthenticated http.Handler, authPublic bool) http.Handler {
+	if authPublic {
+		return nil
+	}
+	return unauthenticated
+}
+
 // checkModuleAvailability checks that all names given in modules are actually
 // available API services. It assumes that the MetadataApi module ("rpc") is always available;
 // the registration of this "rpc" module happens in NewServer() and is thus common to all endpoints.
@@ -2,14 +2,18 @@ package rpc
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 )
@@ -273,3 +277,97 @@ func rpcRequest(t *testing.T, url string, extraHeaders ...string) *http.Response
 	}
 	return resp
 }
+
+type testAdminService struct{}
+
+func (s *testAdminService) Echo(str string) string {
+	return str
+}
+
+// TestJWT makes sure non-public modules are only served to requests authenticated with a valid jwt.
+func TestJWT(t *testing.T) {
+	secret := []byte("secretsecretsecretsecretsecretse")
+	issueToken := func(secret []byte, iat time.Time) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iat": iat.Unix()})
+		signed, err := token.SignedString(secret)
+		assert.NoError(t, err)
+		return "Bearer " + signed
+	}
+	apis := []rpc.API{
+		{Namespace: "orc", Service: &testAdminService{}, Public: true},
+		{Namespace: "admin", Service: &testAdminService{}, Public: false},
+	}
+	modules := []string{"orc", "admin"}
+
+	srv := newHTTPServer(rpc.DefaultHTTPTimeouts)
+	assert.NoError(t, srv.enableRPC(apis, httpConfig{Modules: modules, jwtSecret: secret}))
+	assert.NoError(t, srv.setListenAddr("localhost", 0))
+	assert.NoError(t, srv.start())
+	defer srv.stop()
+	url := "http://" + srv.listenAddr()
+
+	// requests without a token only reach the public modules
+	resp := rpcRequest(t, url)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"orc", "rpc"}, supportedModules(t, resp))
+
+	resp = rpcRequest(t, url, "Authorization", issueToken(secret, time.Now()))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"admin", "orc", "rpc"}, supportedModules(t, resp))
+
+	for _, token := range []string{
+		issueToken([]byte("badsecretbadsecretbadsecretbadse"), time.Now()),
+		issueToken(secret, time.Now().Add(-2*jwtExpiryTimeout)),
+		issueToken(secret, time.Now().Add(2*jwtExpiryTimeout)),
+		"Bearer invalid",
+	} {
+		resp = rpcRequest(t, url, "Authorization", token)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+
+	// public modules require authentication as well
+	authSrv := newHTTPServer(rpc.DefaultHTTPTimeouts)
+	assert.NoError(t, authSrv.enableRPC(apis, httpConfig{Modules: modules, jwtSecret: secret, authPublic: true}))
+	assert.NoError(t, authSrv.setListenAddr("localhost", 0))
+	assert.NoError(t, authSrv.start())
+	defer authSrv.stop()
+	url = "http://" + authSrv.listenAddr()
+
+	resp = rpcRequest(t, url)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp = rpcRequest(t, url, "Authorization", issueToken(secret, time.Now()))
+	assert.Equal(t, []string{"admin", "orc", "rpc"}, supportedModules(t, resp))
+}
+
+// TestNonPublicModulesWithoutJWT makes sure non-public modules are never served when jwt authentication is disabled.
+func TestNonPublicModulesWithoutJWT(t *testing.T) {
+	apis := []rpc.API{
+		{Namespace: "orc", Service: &testAdminService{}, Public: true},
+		{Namespace: "admin", Service: &testAdminService{}, Public: false},
+	}
+	srv := newHTTPServer(rpc.DefaultHTTPTimeouts)
+	assert.NoError(t, srv.enableRPC(apis, httpConfig{Modules: []string{"orc", "admin"}}))
+	assert.NoError(t, srv.setListenAddr("localhost", 0))
+	assert.NoError(t, srv.start())
+	defer srv.stop()
+
+	resp := rpcRequest(t, "http://"+srv.listenAddr())
+	assert.Equal(t, []string{"orc", "rpc"}, supportedModules(t, resp))
+}
+
+// supportedModules decodes the response of a rpc_modules request.
+func supportedModules(t *testing.T, resp *http.Response) []string {
+	t.Helper()
+	defer resp.Body.Close()
+
+	var result struct {
+		Result map[string]string `json:"result"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	modules := make([]string, 0, len(result.Result))
+	for module := range result.Result {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	return modules
+}
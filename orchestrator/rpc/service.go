@@ -7,7 +7,10 @@ import (
 	conIface "github.com/lukso-network/lukso-orchestrator/orchestrator/consensus/iface"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/admin"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/events"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/proposer"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/slots"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain/iface"
 	"sync"
 	"time"
@@ -17,11 +20,25 @@ import (
 type Config struct {
 	ConsensusInfoFeed            iface.ConsensusInfoFeed
 	VerifiedSlotInfoFeed         conIface.VerifiedSlotInfoFeed
+	SyncStatusReader             conIface.SyncStatusReader
+	VanguardController           admin.VanguardController
+	PandoraController            admin.PandoraController
+	SlotReverter                 admin.SlotReverter
+	ReconnectEventFeeds          []admin.ReconnectEventFeed
+	HeaderDivergenceFeed         admin.HeaderDivergenceFeed
 	Db                           db.Database
 	VanguardPendingShardingCache cache.VanguardShardCache
 	PandoraPendingHeaderCache    cache.PandoraHeaderCache
 	// ipc config
 	IPCPath string
+	// jwt authentication config. The non-public namespaces are only served to authenticated
+	// http and websocket clients, the public ones as well when AuthPublicAPIs is set.
+	JWTSecret      []byte
+	AuthPublicAPIs bool
+	// tls config shared by the http and websocket servers. Setting a client CA enables mutual TLS.
+	TLSCertFile     string
+	TLSKeyFile      string
+	TLSClientCAFile string
 	// http config
 	HTTPEnable       bool
 	HTTPHost         string
@@ -37,6 +54,7 @@ type Config struct {
 	WSPort       int
 	WSPathPrefix string
 	WSOrigins    []string
+	WSModules    []string
 }
 
 // Service defining an RPC server for a orchestrator node.
@@ -49,17 +67,26 @@ type Service struct {
 	stop           chan struct{} // Channel to wait for termination notifications
 
 	backend       *api.Backend
+	subscribers   *events.SubscriberRegistry
 	config        *Config
-	rpcAPIs       []rpc.API   // List of APIs currently provided by the node
-	http          *httpServer //
-	ws            *httpServer //
-	ipc           *ipcServer  // Stores information about the ipc http server
-	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
+	rpcAPIs       []rpc.API     // List of APIs currently provided by the node
+	http          *httpServer   //
+	ws            *httpServer   //
+	ipc           *ipcServer    // Stores information about the ipc http server
+	certs         *certReloader // TLS certificates of the http and websocket servers, nil when TLS is disabled
+	inprocHandler *rpc.Server   // In-process RPC request handler to process the API requests
 }
 
 // NewService instantiates a new RPC service instance that will
 // be registered into a running orchestrator node.
 func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if err := validatePrefix("HTTP", cfg.HTTPPathPrefix); err != nil {
+		return nil, err
+	}
+	if err := validatePrefix("WebSocket", cfg.WSPathPrefix); err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
 
@@ -68,6 +95,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		cancel:        cancel,
 		config:        cfg,
 		inprocHandler: rpc.NewServer(),
+		subscribers:   events.NewSubscriberRegistry(),
 		backend: &api.Backend{
 			ConsensusInfoFeed:            cfg.ConsensusInfoFeed,
 			ConsensusInfoDB:              cfg.Db,
@@ -76,14 +104,26 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 			PandoraPendingHeaderCache:    cfg.PandoraPendingHeaderCache,
 			VanguardPendingShardingCache: cfg.VanguardPendingShardingCache,
 			VerifiedSlotInfoFeed:         cfg.VerifiedSlotInfoFeed,
+			SyncStatusReader:             cfg.SyncStatusReader,
 		},
 	}
 	// Configure RPC servers.
 	service.rpcAPIs = service.APIs()
-	service.http = newHTTPServer(rpc.DefaultHTTPTimeouts)
-	service.ws = newHTTPServer(rpc.DefaultHTTPTimeouts)
+	service.http = newHTTPServer(cfg.HTTPTimeouts)
+	service.ws = newHTTPServer(cfg.HTTPTimeouts)
 	service.ipc = newIPCServer(service.config.IPCPath)
 
+	// a client CA without a key pair would silently leave the servers unencrypted
+	if cfg.TLSCertFile != "" || cfg.TLSKeyFile != "" || cfg.TLSClientCAFile != "" {
+		certs, err := newCertReloader(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		service.certs = certs
+		service.http.tlsConfig = certs.tlsConfig()
+		service.ws.tlsConfig = service.http.tlsConfig
+	}
+
 	return service, nil
 }
 
@@ -94,6 +134,10 @@ func (s *Service) Start() {
 		return
 	}
 
+	if s.certs != nil {
+		go s.certs.reloadOnSignal(s.ctx.Done())
+	}
+
 	go func() {
 		// start RPC endpoints
 		err := s.startRPC()
@@ -146,10 +190,12 @@ func (s *Service) startRPC() error {
 	// Configure HTTP.
 	if s.config.HTTPEnable && s.config.HTTPHost != "" {
 		config := httpConfig{
-			CorsAllowedOrigins: nil,
-			Vhosts:             nil,
-			Modules:            nil,
-			prefix:             "",
+			CorsAllowedOrigins: s.config.HTTPCors,
+			Vhosts:             s.config.HTTPVirtualHosts,
+			Modules:            s.config.HTTPModules,
+			prefix:             s.config.HTTPPathPrefix,
+			jwtSecret:          s.config.JWTSecret,
+			authPublic:         s.config.AuthPublicAPIs,
 		}
 		if err := s.http.setListenAddr(s.config.HTTPHost, s.config.HTTPPort); err != nil {
 			return err
@@ -163,9 +209,11 @@ func (s *Service) startRPC() error {
 	if s.config.WSEnable && s.config.WSHost != "" {
 		server := s.wsServerForPort(s.config.WSPort)
 		config := wsConfig{
-			Modules: nil,
-			Origins: []string{"*"},
-			prefix:  "",
+			Modules:    s.config.WSModules,
+			Origins:    s.config.WSOrigins,
+			prefix:     s.config.WSPathPrefix,
+			jwtSecret:  s.config.JWTSecret,
+			authPublic: s.config.AuthPublicAPIs,
 		}
 		if err := server.setListenAddr(s.config.WSHost, s.config.WSPort); err != nil {
 			return err
@@ -226,8 +274,40 @@ func (s *Service) APIs() []rpc.API {
 		{
 			Namespace: "orc",
 			Version:   "1.0",
-			Service:   events.NewPublicFilterAPI(s.backend, 5*time.Minute),
+			Service:   events.NewPublicFilterAPI(s.backend, 5*time.Minute, s.subscribers),
 			Public:    true,
 		},
+		{
+			Namespace: "orc",
+			Version:   "1.0",
+			Service:   proposer.NewPublicProposerAPI(s.config.Db),
+			Public:    true,
+		},
+		{
+			Namespace: "orc",
+			Version:   "1.0",
+			Service: slots.NewPublicSlotAPI(&slots.Config{
+				VerifiedSlotInfoDB:           s.config.Db,
+				InvalidSlotInfoDB:            s.config.Db,
+				VanguardPendingShardingCache: s.config.VanguardPendingShardingCache,
+				PandoraPendingHeaderCache:    s.config.PandoraPendingHeaderCache,
+			}),
+			Public: true,
+		},
+		{
+			Namespace: "admin",
+			Version:   "1.0",
+			Service: admin.NewPrivateAdminAPI(&admin.Config{
+				VanguardController:           s.config.VanguardController,
+				PandoraController:            s.config.PandoraController,
+				SlotReverter:                 s.config.SlotReverter,
+				VanguardPendingShardingCache: s.config.VanguardPendingShardingCache,
+				PandoraPendingHeaderCache:    s.config.PandoraPendingHeaderCache,
+				Subscribers:                  s.subscribers,
+				ReconnectEventFeeds:          s.config.ReconnectEventFeeds,
+				HeaderDivergenceFeed:         s.config.HeaderDivergenceFeed,
+			}),
+			Public: false,
+		},
 	}
 }
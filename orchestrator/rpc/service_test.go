@@ -2,6 +2,9 @@ package rpc
 
 import (
 	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/consensus"
 	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
@@ -11,15 +14,18 @@ import (
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	"testing"
+	"time"
 )
 
 func setup(t *testing.T) (*Config, error) {
 	orchestratorDB := testDB.SetupDB(t)
 	consensusInfoFeed, err := vanguardchain.NewService(
 		context.Background(),
-		cmd.DefaultVanguardGRPCEndpoint,
+		[]string{cmd.DefaultVanguardGRPCEndpoint},
+		nil,
 		orchestratorDB,
 		cache.NewVanShardInfoCache(1<<10),
+		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -44,6 +50,7 @@ func setup(t *testing.T) (*Config, error) {
 		HTTPEnable:           true,
 		HTTPHost:             cmd.DefaultHTTPHost,
 		HTTPPort:             9874,
+		HTTPVirtualHosts:     cmd.DefaultHTTPVirtualHosts,
 		WSEnable:             true,
 		WSHost:               cmd.DefaultWSHost,
 		WSPort:               9875,
@@ -72,3 +79,114 @@ func TestServerStart_Success(t *testing.T) {
 	hook.Reset()
 	assert.NoError(t, rpcService.Stop())
 }
+
+func TestNewService_InvalidPathPrefix(t *testing.T) {
+	config, err := setup(t)
+	require.NoError(t, err)
+
+	config.HTTPPathPrefix = "rpc"
+	_, err = NewService(context.Background(), config)
+	assert.ErrorContains(t, `does not contain leading "/"`, err)
+
+	config.HTTPPathPrefix = ""
+	config.WSPathPrefix = "/ws?x"
+	_, err = NewService(context.Background(), config)
+	assert.ErrorContains(t, "contains URL meta-characters", err)
+}
+
+func TestServerStart_ModulesAndPathPrefix(t *testing.T) {
+	config, err := setup(t)
+	require.NoError(t, err)
+	config.IPCPath = ""
+	config.WSEnable = false
+	config.HTTPPort = 9876
+	config.HTTPPathPrefix = "/rpc"
+	config.HTTPModules = []string{"orc", "admin"}
+
+	rpcService, err := NewService(context.Background(), config)
+	require.NoError(t, err)
+	require.NoError(t, rpcService.startRPC())
+	defer func() {
+		assert.NoError(t, rpcService.Stop())
+	}()
+
+	client, err := rpc.Dial(fmt.Sprintf("http://%s:%d/rpc", config.HTTPHost, config.HTTPPort))
+	require.NoError(t, err)
+	defer client.Close()
+
+	modules, err := client.SupportedModules()
+	require.NoError(t, err)
+	_, hasOrc := modules["orc"]
+	assert.Equal(t, true, hasOrc)
+	// whitelisted non-public namespaces are only served to jwt authenticated clients
+	_, hasAdmin := modules["admin"]
+	assert.Equal(t, false, hasAdmin)
+	var res bool
+	assert.NotNil(t, client.Call(&res, "admin_setVerbosity", "info"))
+
+	// json-rpc is not served outside of the configured prefix
+	client, err = rpc.Dial(fmt.Sprintf("http://%s:%d", config.HTTPHost, config.HTTPPort))
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.SupportedModules()
+	assert.NotNil(t, err)
+}
+
+func TestServerStart_AdminNotServedByDefault(t *testing.T) {
+	config, err := setup(t)
+	require.NoError(t, err)
+	config.IPCPath = ""
+	config.WSEnable = false
+	config.HTTPPort = 9877
+
+	rpcService, err := NewService(context.Background(), config)
+	require.NoError(t, err)
+	require.NoError(t, rpcService.startRPC())
+	defer func() {
+		assert.NoError(t, rpcService.Stop())
+	}()
+
+	client, err := rpc.Dial(fmt.Sprintf("http://%s:%d", config.HTTPHost, config.HTTPPort))
+	require.NoError(t, err)
+	defer client.Close()
+
+	// the admin namespace is only reachable over ipc unless explicitly enabled
+	var res bool
+	assert.NotNil(t, client.Call(&res, "admin_setVerbosity", "info"))
+}
+
+func TestServerStart_AdminRequiresJWT(t *testing.T) {
+	config, err := setup(t)
+	require.NoError(t, err)
+	config.IPCPath = ""
+	config.WSEnable = false
+	config.HTTPPort = 9878
+	config.HTTPModules = []string{"orc", "admin"}
+	config.JWTSecret = []byte("secretsecretsecretsecretsecretse")
+
+	rpcService, err := NewService(context.Background(), config)
+	require.NoError(t, err)
+	require.NoError(t, rpcService.startRPC())
+	defer func() {
+		assert.NoError(t, rpcService.Stop())
+	}()
+
+	client, err := rpc.Dial(fmt.Sprintf("http://%s:%d", config.HTTPHost, config.HTTPPort))
+	require.NoError(t, err)
+	defer client.Close()
+
+	var res bool
+	assert.NotNil(t, client.Call(&res, "admin_setVerbosity", "info"))
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iat": time.Now().Unix()})
+	signed, err := token.SignedString(config.JWTSecret)
+	require.NoError(t, err)
+	client.SetHeader("Authorization", "Bearer "+signed)
+
+	modules, err := client.SupportedModules()
+	require.NoError(t, err)
+	_, hasAdmin := modules["admin"]
+	assert.Equal(t, true, hasAdmin)
+	require.NoError(t, client.Call(&res, "admin_setVerbosity", "info"))
+	assert.Equal(t, true, res)
+}
@@ -0,0 +1,165 @@
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCertificate writes a self signed certificate for localhost and its key to the given directory.
+func writeCertificate(t *testing.T, dir string, serial int64) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile = filepath.Join(dir, "rpc.crt"), filepath.Join(dir, "rpc.key")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
+
+func createAndStartTLSServer(t *testing.T, certs *certReloader) *httpServer {
+	t.Helper()
+
+	srv := newHTTPServer(rpc.DefaultHTTPTimeouts)
+	srv.tlsConfig = certs.tlsConfig()
+	require.NoError(t, srv.enableRPC(nil, httpConfig{}))
+	require.NoError(t, srv.setListenAddr("localhost", 0))
+	require.NoError(t, srv.start())
+	return srv
+}
+
+// servedSerial returns the serial number of the certificate served on the given address.
+func servedSerial(t *testing.T, addr string, clientCert ...tls.Certificate) (int64, error) {
+	t.Helper()
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true, Certificates: clientCert})
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	// the server verifies the client certificate while completing the handshake
+	if err := conn.Handshake(); err != nil {
+		return 0, err
+	}
+	_, err = conn.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\n\r\n"))
+	if err != nil {
+		return 0, err
+	}
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		return 0, err
+	}
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64(), nil
+}
+
+// TestTLS makes sure the http server serves TLS and picks up reloaded certificates.
+func TestTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir, 1)
+	certs, err := newCertReloader(certFile, keyFile, "")
+	require.NoError(t, err)
+
+	srv := createAndStartTLSServer(t, certs)
+	defer srv.stop()
+
+	serial, err := servedSerial(t, srv.listenAddr())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), serial)
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	resp, err := client.Get("https://" + srv.listenAddr())
+	require.NoError(t, err)
+	resp.Body.Close()
+	// plaintext requests are rejected
+	resp, err = http.Get("http://" + srv.listenAddr())
+	if err == nil {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	writeCertificate(t, dir, 2)
+	require.NoError(t, certs.reload())
+	serial, err = servedSerial(t, srv.listenAddr())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), serial)
+
+	// a broken certificate keeps the previous one in place
+	require.NoError(t, ioutil.WriteFile(certFile, []byte("broken"), 0600))
+	assert.Error(t, certs.reload())
+	serial, err = servedSerial(t, srv.listenAddr())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), serial)
+}
+
+// TestTLS_ClientCA makes sure clients must present a certificate signed by the client CA.
+func TestTLS_ClientCA(t *testing.T) {
+	certFile, keyFile := writeCertificate(t, t.TempDir(), 1)
+	clientCertFile, clientKeyFile := writeCertificate(t, t.TempDir(), 2)
+	certs, err := newCertReloader(certFile, keyFile, clientCertFile)
+	require.NoError(t, err)
+
+	srv := createAndStartTLSServer(t, certs)
+	defer srv.stop()
+
+	_, err = servedSerial(t, srv.listenAddr())
+	assert.Error(t, err)
+
+	clientCert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+	require.NoError(t, err)
+	serial, err := servedSerial(t, srv.listenAddr(), clientCert)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), serial)
+}
+
+func TestNewCertReloader_Invalid(t *testing.T) {
+	certFile, _ := writeCertificate(t, t.TempDir(), 1)
+
+	_, err := newCertReloader(certFile, "", "")
+	assert.Equal(t, errMissingTLSKeyPair, err)
+
+	_, err = newCertReloader(certFile, filepath.Join(t.TempDir(), "missing.key"), "")
+	assert.Error(t, err)
+}
+
+func TestNewService_ClientCAWithoutKeyPair(t *testing.T) {
+	config, err := setup(t)
+	require.NoError(t, err)
+	caFile, _ := writeCertificate(t, t.TempDir(), 1)
+	config.TLSClientCAFile = caFile
+
+	_, err = NewService(context.Background(), config)
+	assert.Equal(t, errMissingTLSKeyPair, err)
+}
@@ -0,0 +1,126 @@
+package vanguardchain
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+)
+
+// time to wait between two polls of the vanguard chain head.
+var chainHeadPollPeriod = 2 * time.Second
+
+// pollChainHead polls the chain head of the connected vanguard node until the service stops. Failed polls
+// are skipped, reconnecting is left to the block and consensus info subscriptions.
+func (s *Service) pollChainHead(ctx context.Context) {
+	ticker := time.NewTicker(chainHeadPollPeriod)
+	defer ticker.Stop()
+
+	for {
+		if s.IsConnected() {
+			if err := s.fetchChainHead(ctx); err != nil {
+				log.WithError(err).Debug("Could not fetch vanguard chain head")
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.Info("Received cancelled context, exiting vanguard chain head polling")
+			return
+		}
+	}
+}
+
+// fetchChainHead requests the chain head of the connected vanguard node and publishes it when it changed.
+func (s *Service) fetchChainHead(ctx context.Context) error {
+	client := s.activeBeaconClient()
+	if client == nil {
+		return errNotConnected
+	}
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	chainHead, err := client.GetChainHead(ctx, &empty.Empty{})
+	if err != nil {
+		return err
+	}
+	return s.onNewChainHead(newChainHead(chainHead))
+}
+
+// onNewChainHead stores and publishes a changed vanguard chain head.
+func (s *Service) onNewChainHead(chainHead *types.VanguardChainHead) error {
+	s.processingLock.Lock()
+	if s.chainHead != nil && *s.chainHead == *chainHead {
+		s.processingLock.Unlock()
+		return nil
+	}
+	s.chainHead = chainHead
+	s.processingLock.Unlock()
+
+	s.setHeadSlot(chainHead.HeadSlot)
+	vanguardJustifiedEpoch.Set(float64(chainHead.JustifiedEpoch))
+	vanguardFinalizedEpoch.Set(float64(chainHead.FinalizedEpoch))
+	if err := s.db.SaveVanguardChainHead(chainHead); err != nil {
+		log.WithError(err).Warn("failed to save vanguard chain head")
+		return err
+	}
+
+	log.WithField("headSlot", chainHead.HeadSlot).WithField("justifiedEpoch", chainHead.JustifiedEpoch).
+		WithField("finalizedSlot", chainHead.FinalizedSlot).WithField("finalizedEpoch", chainHead.FinalizedEpoch).
+		Debug("New vanguard chain head")
+	s.chainHeadFeed.Send(chainHead)
+	return nil
+}
+
+// restoreChainHead loads the chain head saved before the last shutdown, so it is known before the first poll.
+func (s *Service) restoreChainHead() {
+	chainHead, err := s.db.VanguardChainHead()
+	if err != nil {
+		log.WithError(err).Warn("Failed to load the saved vanguard chain head")
+		return
+	}
+	if chainHead == nil {
+		return
+	}
+	s.processingLock.Lock()
+	s.chainHead = chainHead
+	s.processingLock.Unlock()
+	vanguardJustifiedEpoch.Set(float64(chainHead.JustifiedEpoch))
+	vanguardFinalizedEpoch.Set(float64(chainHead.FinalizedEpoch))
+	log.WithField("headSlot", chainHead.HeadSlot).WithField("finalizedEpoch", chainHead.FinalizedEpoch).
+		Debug("Restored vanguard chain head")
+}
+
+// ChainHead returns the latest known vanguard chain head, or nil before the first one arrived.
+func (s *Service) ChainHead() *types.VanguardChainHead {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	if s.chainHead == nil {
+		return nil
+	}
+	cpy := *s.chainHead
+	return &cpy
+}
+
+// SubscribeChainHeadEvent registers a subscription of the changes of the vanguard chain head.
+func (s *Service) SubscribeChainHeadEvent(ch chan<- *types.VanguardChainHead) event.Subscription {
+	return s.scope.Track(s.chainHeadFeed.Subscribe(ch))
+}
+
+func newChainHead(chainHead *ethpb.ChainHead) *types.VanguardChainHead {
+	return &types.VanguardChainHead{
+		HeadSlot:           uint64(chainHead.HeadSlot),
+		HeadEpoch:          uint64(chainHead.HeadEpoch),
+		HeadBlockRoot:      common.BytesToHash(chainHead.HeadBlockRoot),
+		JustifiedSlot:      uint64(chainHead.JustifiedSlot),
+		JustifiedEpoch:     uint64(chainHead.JustifiedEpoch),
+		JustifiedBlockRoot: common.BytesToHash(chainHead.JustifiedBlockRoot),
+		FinalizedSlot:      uint64(chainHead.FinalizedSlot),
+		FinalizedEpoch:     uint64(chainHead.FinalizedEpoch),
+		FinalizedBlockRoot: common.BytesToHash(chainHead.FinalizedBlockRoot),
+	}
+}
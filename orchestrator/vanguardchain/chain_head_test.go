@@ -0,0 +1,76 @@
+package vanguardchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_PollChainHead(t *testing.T) {
+	defer func(period time.Duration) { chainHeadPollPeriod = period }(chainHeadPollPeriod)
+	chainHeadPollPeriod = 50 * time.Millisecond
+	endpoint, _ := startBeaconChainServer(t, 20, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db := dbSetup(ctx, t, 5)
+	s, err := NewService(ctx, []string{endpoint}, nil, db, nil, nil)
+	require.NoError(t, err)
+	defer s.Stop()
+	require.NoError(t, s.connect())
+
+	chainHeadCh := make(chan *types.VanguardChainHead, 10)
+	sub := s.SubscribeChainHeadEvent(chainHeadCh)
+	defer sub.Unsubscribe()
+	go s.pollChainHead(ctx)
+
+	select {
+	case chainHead := <-chainHeadCh:
+		assert.Equal(t, uint64(20), chainHead.HeadSlot)
+	case <-time.After(time.Second):
+		t.Fatal("Did not receive the vanguard chain head")
+	}
+	assert.Equal(t, uint64(20), s.HeadSlot())
+	assert.Equal(t, uint64(20), s.ChainHead().HeadSlot)
+	saved, err := db.VanguardChainHead()
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, uint64(20), saved.HeadSlot)
+
+	// unchanged chain heads are not published again
+	time.Sleep(4 * chainHeadPollPeriod)
+	assert.Equal(t, 0, len(chainHeadCh))
+}
+
+func TestService_HeadSlotAfterRollback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := NewService(ctx, []string{"127.0.0.1:0"}, nil, dbSetup(ctx, t, 5), nil, nil)
+	require.NoError(t, err)
+
+	s.updateHeadSlot(40)
+	s.updateHeadSlot(35)
+	assert.Equal(t, uint64(40), s.HeadSlot())
+
+	// the head reported by the node after a revert replaces the highest streamed slot
+	require.NoError(t, s.onNewChainHead(&types.VanguardChainHead{HeadSlot: 32}))
+	assert.Equal(t, uint64(32), s.HeadSlot())
+}
+
+func TestService_RestoreChainHead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db := dbSetup(ctx, t, 5)
+	saved := &types.VanguardChainHead{HeadSlot: 40, FinalizedSlot: 8, FinalizedEpoch: 1}
+	require.NoError(t, db.SaveVanguardChainHead(saved))
+	s, err := NewService(ctx, []string{"127.0.0.1:0"}, nil, db, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.VanguardChainHead)(nil), s.ChainHead())
+
+	s.restoreChainHead()
+	assert.DeepEqual(t, saved, s.ChainHead())
+}
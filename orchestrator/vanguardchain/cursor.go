@@ -0,0 +1,78 @@
+package vanguardchain
+
+var (
+	// blockCursorOverlap is the number of slots before the block cursor which are streamed again on resumption,
+	// so blocks which were in flight when the stream broke are not missed.
+	blockCursorOverlap = uint64(2)
+	// epochCursorOverlap is the number of epochs before the epoch cursor which are streamed again on resumption.
+	epochCursorOverlap = uint64(1)
+)
+
+// streamCursor is the position of the vanguard streams, broken streams resume right after it instead of
+// replaying everything since the latest finalized slot and epoch. The vanguard node resumes streams by slot
+// only, already forwarded blocks of the overlap are recognized by their root.
+type streamCursor struct {
+	hasBlock bool
+	slot     uint64 // highest slot processed by the block stream
+	hasEpoch bool
+	epoch    uint64 // highest epoch processed by the consensus info stream
+}
+
+// advanceBlockCursor moves the block cursor to a processed block unless a higher slot was processed already.
+func (s *Service) advanceBlockCursor(slot uint64) {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+
+	if s.cursor.hasBlock && slot < s.cursor.slot {
+		return
+	}
+	s.cursor.hasBlock = true
+	s.cursor.slot = slot
+}
+
+// advanceEpochCursor moves the epoch cursor to a processed epoch unless a higher epoch was processed already.
+func (s *Service) advanceEpochCursor(epoch uint64) {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+
+	if s.cursor.hasEpoch && epoch < s.cursor.epoch {
+		return
+	}
+	s.cursor.hasEpoch = true
+	s.cursor.epoch = epoch
+}
+
+// blockResumeSlot returns the slot a broken block stream resumes from, a few slots before the block cursor
+// but never below the latest finalized slot.
+func (s *Service) blockResumeSlot() uint64 {
+	finalizedSlot := s.db.LatestLatestFinalizedSlot()
+
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	if !s.cursor.hasBlock {
+		return finalizedSlot
+	}
+	log.WithField("cursorSlot", s.cursor.slot).Debug("Resuming vanguard blocks from cursor")
+	return resumePoint(s.cursor.slot, blockCursorOverlap, finalizedSlot)
+}
+
+// epochResumeEpoch returns the epoch a broken consensus info stream resumes from, an epoch before the epoch
+// cursor but never below the latest finalized epoch.
+func (s *Service) epochResumeEpoch() uint64 {
+	finalizedEpoch := s.db.LatestLatestFinalizedEpoch()
+
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	if !s.cursor.hasEpoch {
+		return finalizedEpoch
+	}
+	return resumePoint(s.cursor.epoch, epochCursorOverlap, finalizedEpoch)
+}
+
+// resumePoint returns the cursor minus the overlap, bounded below by the lowest point.
+func resumePoint(cursor, overlap, lowest uint64) uint64 {
+	if cursor < lowest+overlap {
+		return lowest
+	}
+	return cursor - overlap
+}
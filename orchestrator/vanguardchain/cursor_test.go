@@ -0,0 +1,60 @@
+package vanguardchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+)
+
+func TestResumePoint(t *testing.T) {
+	assert.Equal(t, uint64(48), resumePoint(50, 2, 32))
+	assert.Equal(t, uint64(32), resumePoint(33, 2, 32))
+	assert.Equal(t, uint64(32), resumePoint(10, 2, 32))
+	assert.Equal(t, uint64(0), resumePoint(1, 2, 0))
+}
+
+func TestService_StreamCursor(t *testing.T) {
+	s, _ := serviceInit(t, 5)
+	finalizedSlot := s.db.LatestLatestFinalizedSlot()
+	finalizedEpoch := s.db.LatestLatestFinalizedEpoch()
+
+	// without cursor the streams resume from finality
+	assert.Equal(t, finalizedSlot, s.blockResumeSlot())
+	assert.Equal(t, finalizedEpoch, s.epochResumeEpoch())
+
+	shardInfoCh := make(chan *types.VanguardShardInfo, 10)
+	sub := s.SubscribeShardInfoEvent(shardInfoCh)
+	defer sub.Unsubscribe()
+	blockInfos := make([]*ethpb.StreamPendingBlockInfo, 0, 10)
+	for slot := finalizedSlot + 1; slot <= finalizedSlot+10; slot++ {
+		block := testutil.NewBeaconBlock(slot)
+		block.Body.PandoraShard[0].SealHash = make([]byte, 32)
+		blockInfo := &ethpb.StreamPendingBlockInfo{Block: block, FinalizedSlot: eth2Types.Slot(finalizedSlot)}
+		blockInfos = append(blockInfos, blockInfo)
+		require.NoError(t, s.onNewPendingVanguardBlock(context.Background(), blockInfo))
+	}
+	assert.Equal(t, 10, len(shardInfoCh))
+	assert.Equal(t, finalizedSlot+10-blockCursorOverlap, s.blockResumeSlot())
+
+	// replayed blocks of the overlap are dropped
+	for _, blockInfo := range blockInfos[10-blockCursorOverlap:] {
+		require.NoError(t, s.onNewPendingVanguardBlock(context.Background(), blockInfo))
+	}
+	assert.Equal(t, 10, len(shardInfoCh))
+	assert.Equal(t, finalizedSlot+10-blockCursorOverlap, s.blockResumeSlot())
+
+	s.advanceEpochCursor(finalizedEpoch + 3)
+	s.advanceEpochCursor(finalizedEpoch + 1)
+	assert.Equal(t, finalizedEpoch+3-epochCursorOverlap, s.epochResumeEpoch())
+
+	// explicitly requested re-subscriptions start from scratch
+	s.resetSentEvents()
+	assert.Equal(t, finalizedSlot, s.blockResumeSlot())
+	assert.Equal(t, finalizedEpoch, s.epochResumeEpoch())
+}
@@ -0,0 +1,133 @@
+package vanguardchain
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"math"
+	"time"
+
+	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var (
+	errMissingClientKeyPair = errors.New("both vanguard TLS client certificate and key must be set")
+	errInvalidCACert        = errors.New("no valid certificate found in vanguard TLS CA file")
+)
+
+// DialConfig holds the options of the grpc connection with the vanguard node
+type DialConfig struct {
+	TLSCACert          string        // CA certificate the vanguard node certificate is verified with, enables TLS
+	TLSClientCert      string        // client certificate presented to the vanguard node (mutual TLS)
+	TLSClientKey       string        // private key of the client certificate
+	BearerToken        string        // token sent within the authorization header of every call
+	MaxCallRecvMsgSize int           // maximum size in bytes of a received message
+	GRPCRetries        uint          // number of retries of a failed unary call
+	GRPCRetryDelay     time.Duration // base of the exponential backoff between the retries of a failed unary call
+}
+
+// DefaultDialConfig returns the insecure dial config used when none is given
+func DefaultDialConfig() *DialConfig {
+	return &DialConfig{
+		MaxCallRecvMsgSize: math.MaxInt32,
+		GRPCRetries:        5,
+		GRPCRetryDelay:     100 * time.Millisecond,
+	}
+}
+
+// tlsEnabled returns true when the connection with the vanguard node is secured by TLS
+func (cfg *DialConfig) tlsEnabled() bool {
+	return cfg.TLSCACert != "" || cfg.TLSClientCert != "" || cfg.TLSClientKey != ""
+}
+
+// constructDialOptions constructs a list of grpc dial options
+func constructDialOptions(cfg *DialConfig, extraOpts ...grpc.DialOption) ([]grpc.DialOption, error) {
+	var transportSecurity grpc.DialOption
+	if cfg.tlsEnabled() {
+		creds, err := transportCredentials(cfg)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get valid credentials")
+		}
+		transportSecurity = grpc.WithTransportCredentials(creds)
+	} else {
+		transportSecurity = grpc.WithInsecure()
+		log.Warn("You are using an insecure gRPC connection. If you are running your vanguard node and " +
+			"orchestrator on the same machines, you can ignore this message. Otherwise use the vanguard TLS flags " +
+			"to enable a secure connection")
+	}
+
+	maxCallRecvMsgSize := cfg.MaxCallRecvMsgSize
+	if maxCallRecvMsgSize == 0 {
+		maxCallRecvMsgSize = 10 * 5 << 20 // Default 50Mb
+	}
+
+	// streams are not retried, a broken stream is resumed by the reconnection backoff and the failover
+	retryOpts := []grpc_retry.CallOption{
+		grpc_retry.WithMax(cfg.GRPCRetries),
+		grpc_retry.WithBackoff(grpc_retry.BackoffExponentialWithJitter(cfg.GRPCRetryDelay, 0.1)),
+	}
+	dialOpts := []grpc.DialOption{
+		transportSecurity,
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxCallRecvMsgSize)),
+		grpc.WithChainUnaryInterceptor(grpc_retry.UnaryClientInterceptor(retryOpts...)),
+	}
+	if cfg.BearerToken != "" {
+		if !cfg.tlsEnabled() {
+			log.Warn("Sending the vanguard bearer token over an insecure gRPC connection")
+		}
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(&bearerTokenCredentials{
+			token:  cfg.BearerToken,
+			secure: cfg.tlsEnabled(),
+		}))
+	}
+
+	dialOpts = append(dialOpts, extraOpts...)
+	return dialOpts, nil
+}
+
+// transportCredentials builds the TLS credentials of the vanguard connection. The system certificate
+// pool is used when no CA certificate is given.
+func transportCredentials(cfg *DialConfig) (credentials.TransportCredentials, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.TLSCACert != "" {
+		pem, err := ioutil.ReadFile(cfg.TLSCACert)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.Wrap(errInvalidCACert, cfg.TLSCACert)
+		}
+	}
+	if cfg.TLSClientCert != "" || cfg.TLSClientKey != "" {
+		if cfg.TLSClientCert == "" || cfg.TLSClientKey == "" {
+			return nil, errMissingClientKeyPair
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.TLSClientCert, cfg.TLSClientKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// bearerTokenCredentials attaches a bearer token to the metadata of every grpc call
+type bearerTokenCredentials struct {
+	token  string
+	secure bool
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials
+func (c *bearerTokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.token}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials
+func (c *bearerTokenCredentials) RequireTransportSecurity() bool {
+	return c.secure
+}
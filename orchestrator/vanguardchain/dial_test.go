@@ -0,0 +1,194 @@
+package vanguardchain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// writeCertificate writes a self signed certificate for 127.0.0.1 and its key to the given directory.
+func writeCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "vanguard"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile = filepath.Join(dir, "vanguard.crt"), filepath.Join(dir, "vanguard.key")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
+
+// startSecureServer starts a grpc health server which requires mutual TLS and the given bearer token.
+func startSecureServer(t *testing.T, certFile, keyFile, clientCAFile, token string) string {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	require.NoError(t, err)
+	pem, err := ioutil.ReadFile(clientCAFile)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	require.Equal(t, true, clientCAs.AppendCertsFromPEM(pem))
+
+	authInterceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if auth := md.Get("authorization"); len(auth) != 1 || auth[0] != "Bearer "+token {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return handler(ctx, req)
+	}
+	server := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientCAs:    clientCAs,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+		})),
+		grpc.UnaryInterceptor(authInterceptor),
+	)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func checkHealth(t *testing.T, addr string, cfg *DialConfig) error {
+	dialOpts, err := constructDialOptions(cfg)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, addr, dialOpts...)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestConstructDialOptions_SecureConnection(t *testing.T) {
+	serverCert, serverKey := writeCertificate(t, t.TempDir())
+	clientCert, clientKey := writeCertificate(t, t.TempDir())
+	addr := startSecureServer(t, serverCert, serverKey, clientCert, "secret")
+
+	cfg := &DialConfig{
+		TLSCACert:     serverCert,
+		TLSClientCert: clientCert,
+		TLSClientKey:  clientKey,
+		BearerToken:   "secret",
+	}
+	require.NoError(t, checkHealth(t, addr, cfg))
+
+	cfg.BearerToken = "wrong"
+	assert.Equal(t, codes.Unauthenticated, status.Code(checkHealth(t, addr, cfg)))
+}
+
+// startFlakyServer starts a grpc health server which fails the first calls and streams as unavailable. The
+// returned counter holds the number of received calls and streams.
+func startFlakyServer(t *testing.T, failures int32) (string, *int32) {
+	var calls int32
+	flakyInterceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			return nil, status.Error(codes.Unavailable, "not ready")
+		}
+		return handler(ctx, req)
+	}
+	flakyStreamInterceptor := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			return status.Error(codes.Unavailable, "not ready")
+		}
+		return handler(srv, ss)
+	}
+	server := grpc.NewServer(grpc.UnaryInterceptor(flakyInterceptor), grpc.StreamInterceptor(flakyStreamInterceptor))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String(), &calls
+}
+
+func TestConstructDialOptions_Retry(t *testing.T) {
+	cfg := &DialConfig{GRPCRetries: 3, GRPCRetryDelay: time.Millisecond}
+
+	// the call succeeds once the server recovers within the retries
+	addr, calls := startFlakyServer(t, 2)
+	require.NoError(t, checkHealth(t, addr, cfg))
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+
+	// the error is returned once all retries are used up
+	addr, calls = startFlakyServer(t, 10)
+	assert.Equal(t, codes.Unavailable, status.Code(checkHealth(t, addr, cfg)))
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestConstructDialOptions_InvalidConfig(t *testing.T) {
+	certFile, keyFile := writeCertificate(t, t.TempDir())
+
+	opts, err := constructDialOptions(DefaultDialConfig())
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(opts))
+
+	opts, err = constructDialOptions(&DialConfig{BearerToken: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, 4, len(opts))
+
+	_, err = constructDialOptions(&DialConfig{TLSCACert: keyFile})
+	assert.ErrorContains(t, errInvalidCACert.Error(), err)
+
+	_, err = constructDialOptions(&DialConfig{TLSCACert: certFile, TLSClientCert: certFile})
+	assert.ErrorContains(t, errMissingClientKeyPair.Error(), err)
+}
+
+func TestConstructDialOptions_StreamNotRetried(t *testing.T) {
+	addr, calls := startFlakyServer(t, 2)
+	dialOpts, err := constructDialOptions(&DialConfig{GRPCRetries: 3, GRPCRetryDelay: time.Millisecond})
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, addr, dialOpts...)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// a broken stream is left to the reconnection of the service
+	stream, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
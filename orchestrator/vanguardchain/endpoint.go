@@ -0,0 +1,224 @@
+package vanguardchain
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/backoff"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// time to wait for the chain head of an endpoint before considering it unhealthy.
+var healthCheckTimeout = 5 * time.Second
+
+var errNoHealthyEndpoint = errors.New("no healthy vanguard endpoint")
+
+// endpointHealth is the result of a health check of a vanguard endpoint
+type endpointHealth struct {
+	endpoint string
+	headSlot uint64
+	latency  time.Duration
+	err      error
+}
+
+// dedupeEndpoints removes empty and duplicated endpoints while keeping the given order.
+func dedupeEndpoints(endpoints []string) []string {
+	seen := make(map[string]bool, len(endpoints))
+	deduped := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if endpoint == "" || seen[endpoint] {
+			continue
+		}
+		seen[endpoint] = true
+		deduped = append(deduped, endpoint)
+	}
+	return deduped
+}
+
+// rankEndpoints sorts the endpoints from the healthiest to the least healthy one. Reachable endpoints
+// come first, ordered by highest head slot and then by lowest latency.
+func rankEndpoints(healths []*endpointHealth) {
+	sort.SliceStable(healths, func(i, j int) bool {
+		a, b := healths[i], healths[j]
+		if (a.err == nil) != (b.err == nil) {
+			return a.err == nil
+		}
+		if a.headSlot != b.headSlot {
+			return a.headSlot > b.headSlot
+		}
+		return a.latency < b.latency
+	})
+}
+
+// probeEndpoint checks the health of an endpoint by requesting its chain head. The active connection is
+// reused for the active endpoint, the other endpoints are dialed for the duration of the check.
+func (s *Service) probeEndpoint(endpoint string) *endpointHealth {
+	health := &endpointHealth{endpoint: endpoint}
+
+	s.processingLock.RLock()
+	client := s.beaconClient
+	active := s.conn != nil && endpoint == s.vanGRPCEndpoint
+	s.processingLock.RUnlock()
+
+	if !active {
+		conn, err := s.dialEndpoint(endpoint)
+		if err != nil {
+			health.err = err
+			return health
+		}
+		defer conn.Close()
+		client = ethpb.NewBeaconChainClient(conn)
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, healthCheckTimeout)
+	defer cancel()
+	start := time.Now()
+	chainHead, err := client.GetChainHead(ctx, &emptypb.Empty{})
+	if err != nil {
+		health.err = err
+		return health
+	}
+	health.latency = time.Since(start)
+	health.headSlot = uint64(chainHead.HeadSlot)
+	return health
+}
+
+// selectEndpoint checks all the endpoints of the pinned network concurrently and returns the healthiest one.
+func (s *Service) selectEndpoint() (*endpointHealth, error) {
+	s.processingLock.RLock()
+	endpoints := make([]string, 0, len(s.endpoints))
+	for _, endpoint := range s.endpoints {
+		if !s.mismatched[endpoint] {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	s.processingLock.RUnlock()
+
+	healths := make([]*endpointHealth, len(endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			healths[i] = s.probeEndpoint(endpoint)
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	for _, health := range healths {
+		if health.err != nil {
+			vanguardEndpointHealthy.WithLabelValues(health.endpoint).Set(0)
+			log.WithError(health.err).WithField("vanguardEndpoint", health.endpoint).Debug("Vanguard endpoint is unhealthy")
+			continue
+		}
+		vanguardEndpointHealthy.WithLabelValues(health.endpoint).Set(1)
+		log.WithField("vanguardEndpoint", health.endpoint).WithField("headSlot", health.headSlot).WithField(
+			"latency", health.latency).Debug("Vanguard endpoint is healthy")
+	}
+
+	rankEndpoints(healths)
+	if len(healths) == 0 || healths[0].err != nil {
+		return nil, errNoHealthyEndpoint
+	}
+	return healths[0], nil
+}
+
+// connect connects to the healthiest vanguard endpoint, failing over from the active one if needed,
+// and verifies the network of the node.
+func (s *Service) connect() error {
+	best, err := s.selectEndpoint()
+	if err != nil {
+		return err
+	}
+	if err := s.useEndpoint(best.endpoint); err != nil {
+		return err
+	}
+	if err := s.verifyNetwork(best.endpoint); err != nil {
+		s.StopSubscription()
+		if errors.Is(err, errNetworkMismatch) && s.excludeEndpoint(best.endpoint) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+
+	s.processingLock.Lock()
+	s.connectionGen++
+	s.processingLock.Unlock()
+
+	s.setHeadSlot(best.headSlot)
+	s.setConnected(true)
+	log.WithField("vanguardEndpoint", best.endpoint).WithField("headSlot", best.headSlot).Info("Connected vanguard chain")
+	return nil
+}
+
+// excludeEndpoint stops connecting to an endpoint of another network. It returns true once no endpoint
+// of the pinned network is left.
+func (s *Service) excludeEndpoint(endpoint string) bool {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	if s.mismatched == nil {
+		s.mismatched = make(map[string]bool)
+	}
+	s.mismatched[endpoint] = true
+	log.WithField("vanguardEndpoint", endpoint).Error("Excluding the vanguard endpoint of another network")
+	for _, endpoint := range s.endpoints {
+		if !s.mismatched[endpoint] {
+			return false
+		}
+	}
+	return true
+}
+
+// useEndpoint makes the given endpoint the active one and dials it, unless it is already connected.
+func (s *Service) useEndpoint(endpoint string) error {
+	s.processingLock.Lock()
+	if s.conn != nil && endpoint == s.vanGRPCEndpoint {
+		s.processingLock.Unlock()
+		return nil
+	}
+	previous := s.vanGRPCEndpoint
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	s.vanGRPCEndpoint = endpoint
+	s.processingLock.Unlock()
+
+	if previous != endpoint {
+		vanguardFailovers.Inc()
+		log.WithField("from", previous).WithField("to", endpoint).Warn("Failing over to another vanguard endpoint")
+	}
+	return s.dialConn()
+}
+
+// reconnect is called by a subscription whose stream broke on the connection of the given generation.
+// The subscriptions share the connection, so only the first of them reconnects and the others reuse it.
+func (s *Service) reconnect(generation uint64) error {
+	s.reconnectLock.Lock()
+	defer s.reconnectLock.Unlock()
+
+	if s.connectionGeneration() != generation {
+		return nil
+	}
+	s.setConnected(false)
+	vanguardReconnects.Inc()
+	return s.waitForConnection()
+}
+
+// connectionGeneration returns the generation of the active connection.
+func (s *Service) connectionGeneration() uint64 {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	return s.connectionGen
+}
+
+// activeBeaconClient returns the beacon chain client of the active connection.
+func (s *Service) activeBeaconClient() ethpb.BeaconChainClient {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	return s.beaconClient
+}
@@ -0,0 +1,188 @@
+package vanguardchain
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/lukso-network/lukso-orchestrator/shared/backoff"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc"
+)
+
+// fakeBeaconChainServer serves a fixed chain head
+type fakeBeaconChainServer struct {
+	ethpb.UnimplementedBeaconChainServer
+	headSlot uint64
+	delay    time.Duration
+}
+
+func (f *fakeBeaconChainServer) GetChainHead(context.Context, *empty.Empty) (*ethpb.ChainHead, error) {
+	time.Sleep(f.delay)
+	return &ethpb.ChainHead{HeadSlot: eth2Types.Slot(f.headSlot)}, nil
+}
+
+// fakeNodeServer serves a fixed genesis
+type fakeNodeServer struct {
+	ethpb.UnimplementedNodeServer
+	genesisTime int64
+}
+
+func (f *fakeNodeServer) GetGenesis(context.Context, *empty.Empty) (*ethpb.Genesis, error) {
+	return &ethpb.Genesis{
+		GenesisTime:           &timestamp.Timestamp{Seconds: f.genesisTime},
+		GenesisValidatorsRoot: make([]byte, 32),
+	}, nil
+}
+
+// startBeaconChainServer starts a fake vanguard node and returns its endpoint along with a function stopping it.
+func startBeaconChainServer(t *testing.T, headSlot uint64, delay time.Duration) (string, func()) {
+	return startNetworkServer(t, headSlot, delay, 1624266000)
+}
+
+// startNetworkServer starts a fake vanguard node of the network with the given genesis time.
+func startNetworkServer(t *testing.T, headSlot uint64, delay time.Duration, genesisTime int64) (string, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	ethpb.RegisterBeaconChainServer(server, &fakeBeaconChainServer{headSlot: headSlot, delay: delay})
+	ethpb.RegisterNodeServer(server, &fakeNodeServer{genesisTime: genesisTime})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String(), server.Stop
+}
+
+func TestDedupeEndpoints(t *testing.T) {
+	assert.DeepEqual(t, []string{"127.0.0.1:4000", "127.0.0.1:4001"},
+		dedupeEndpoints([]string{"127.0.0.1:4000", "", "127.0.0.1:4001", "127.0.0.1:4000"}))
+}
+
+func TestRankEndpoints(t *testing.T) {
+	healths := []*endpointHealth{
+		{endpoint: "down", headSlot: 100, err: errors.New("unavailable")},
+		{endpoint: "behind", headSlot: 9, latency: time.Millisecond},
+		{endpoint: "slow", headSlot: 10, latency: time.Second},
+		{endpoint: "fast", headSlot: 10, latency: time.Millisecond},
+	}
+	rankEndpoints(healths)
+
+	ranked := make([]string, 0, len(healths))
+	for _, health := range healths {
+		ranked = append(ranked, health.endpoint)
+	}
+	assert.DeepEqual(t, []string{"fast", "slow", "behind", "down"}, ranked)
+}
+
+func TestService_Failover(t *testing.T) {
+	defer func(timeout time.Duration) { healthCheckTimeout = timeout }(healthCheckTimeout)
+	healthCheckTimeout = time.Second
+	behind, _ := startBeaconChainServer(t, 10, 0)
+	best, stopBest := startBeaconChainServer(t, 20, 0)
+	// accepts connections but never answers in time
+	stalled, _ := startBeaconChainServer(t, 30, 2*healthCheckTimeout)
+
+	ctx := context.Background()
+	s, err := NewService(ctx, []string{stalled, "127.0.0.1:1", behind, best, behind}, nil,
+		dbSetup(ctx, t, 5), nil, nil)
+	require.NoError(t, err)
+	defer s.Stop()
+	assert.Equal(t, 4, len(s.endpoints))
+
+	require.NoError(t, s.connect())
+	assert.Equal(t, best, s.vanGRPCEndpoint)
+	assert.Equal(t, uint64(20), s.HeadSlot())
+	assert.Equal(t, true, s.IsConnected())
+
+	// concurrent subscriptions of a broken connection fail over only once
+	generation := s.connectionGeneration()
+	stopBest()
+	s.reconnect(generation)
+	assert.Equal(t, behind, s.vanGRPCEndpoint)
+	assert.Equal(t, generation+1, s.connectionGeneration())
+	s.reconnect(generation)
+	assert.Equal(t, generation+1, s.connectionGeneration())
+}
+
+func TestService_SkipsForwardedEvents(t *testing.T) {
+	s, _ := serviceInit(t, 5)
+	shardInfoCh := make(chan *types.VanguardShardInfo, 10)
+	sub := s.SubscribeShardInfoEvent(shardInfoCh)
+	defer sub.Unsubscribe()
+
+	block := testutil.NewBeaconBlock(10)
+	block.Body.PandoraShard[0].SealHash = make([]byte, 32)
+	blockInfo := &ethpb.StreamPendingBlockInfo{Block: block, FinalizedSlot: 5}
+	require.NoError(t, s.onNewPendingVanguardBlock(context.Background(), blockInfo))
+	require.NoError(t, s.onNewPendingVanguardBlock(context.Background(), blockInfo))
+	assert.Equal(t, 1, len(shardInfoCh))
+
+	// explicitly requested re-subscriptions forward blocks again
+	s.resetSentEvents()
+	require.NoError(t, s.onNewPendingVanguardBlock(context.Background(), blockInfo))
+	assert.Equal(t, 2, len(shardInfoCh))
+
+	assert.Equal(t, true, s.markEpochSent(3))
+	assert.Equal(t, false, s.markEpochSent(3))
+	assert.Equal(t, false, s.markEpochSent(2))
+	assert.Equal(t, true, s.markEpochSent(4))
+}
+
+func TestService_VerifyNetwork(t *testing.T) {
+	endpoint, _ := startBeaconChainServer(t, 10, 0)
+	ctx := context.Background()
+	db := dbSetup(ctx, t, 5)
+	s, err := NewService(ctx, []string{endpoint}, nil, db, nil, nil)
+	require.NoError(t, err)
+	defer s.Stop()
+
+	// the network of the first node is pinned
+	require.NoError(t, s.connect())
+	pinned, err := db.VanguardNetwork()
+	require.NoError(t, err)
+	require.NotNil(t, pinned)
+	assert.Equal(t, uint64(1624266000), pinned.GenesisTime)
+	require.NoError(t, s.connect())
+
+	// without any node of the pinned network the service gives up without retrying
+	otherDB := dbSetup(ctx, t, 5)
+	_, err = otherDB.PinVanguardNetwork(&types.VanguardNetwork{GenesisTime: pinned.GenesisTime + 1})
+	require.NoError(t, err)
+	other, err := NewService(ctx, []string{endpoint}, nil, otherDB, nil, nil)
+	require.NoError(t, err)
+	defer other.Stop()
+	require.ErrorContains(t, errNetworkMismatch.Error(), other.waitForConnection())
+	assert.Equal(t, false, other.IsConnected())
+	assert.ErrorContains(t, errNetworkMismatch.Error(), other.runError)
+}
+
+// TestService_NetworkMismatchFailover checks that the healthiest endpoint is excluded when it belongs to
+// another network and the next endpoint of the pinned network is connected instead.
+func TestService_NetworkMismatchFailover(t *testing.T) {
+	otherNetwork, _ := startNetworkServer(t, 20, 0, 1624266001)
+	endpoint, _ := startBeaconChainServer(t, 10, 0)
+	ctx := context.Background()
+	db := dbSetup(ctx, t, 5)
+	_, err := db.PinVanguardNetwork(&types.VanguardNetwork{GenesisTime: 1624266000})
+	require.NoError(t, err)
+	s, err := NewService(ctx, []string{otherNetwork, endpoint}, nil, db, nil,
+		&backoff.Config{InitialInterval: time.Millisecond, Multiplier: 1, MaxAttempts: 10})
+	require.NoError(t, err)
+	defer s.Stop()
+
+	require.NoError(t, s.waitForConnection())
+	assert.Equal(t, endpoint, s.vanGRPCEndpoint)
+	assert.Equal(t, uint64(10), s.headSlot)
+
+	best, err := s.selectEndpoint()
+	require.NoError(t, err)
+	assert.Equal(t, endpoint, best.endpoint)
+}
@@ -0,0 +1,52 @@
+package vanguardchain
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+)
+
+var (
+	errNotConnected  = errors.New("not connected to any vanguard node")
+	errBlockNotFound = errors.New("vanguard block not found")
+)
+
+// BlockBySlot requests the block of the given slot from the connected vanguard node and returns its shard info.
+// The canonical block is preferred when the node knows several blocks of the slot.
+func (s *Service) BlockBySlot(ctx context.Context, slot uint64) (*types.VanguardShardInfo, error) {
+	client := s.activeBeaconClient()
+	if client == nil {
+		return nil, errNotConnected
+	}
+	res, err := client.ListBlocks(ctx, &ethpb.ListBlocksRequest{
+		QueryFilter: &ethpb.ListBlocksRequest_Slot{Slot: eth2Types.Slot(slot)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var container *ethpb.BeaconBlockContainer
+	for _, candidate := range res.BlockContainers {
+		if candidate.GetBlock().GetBlock() == nil {
+			continue
+		}
+		if container == nil || candidate.Canonical {
+			container = candidate
+		}
+		if candidate.Canonical {
+			break
+		}
+	}
+	if container == nil {
+		return nil, errors.Wrapf(errBlockNotFound, "slot %d", slot)
+	}
+
+	chainHead, err := client.GetChainHead(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return newShardInfo(container.Block.Block, uint64(chainHead.FinalizedSlot), uint64(chainHead.FinalizedEpoch))
+}
@@ -10,9 +10,42 @@ import (
 )
 
 // onNewConsensusInfo :
-//	- sends the new consensus info to all subscribed pandora clients
-//  - store consensus info into cache as well as into kv consensusInfoDB
+//   - sends the new consensus info to all subscribed pandora clients
+//   - store consensus info into cache as well as into kv consensusInfoDB
+//   - replaces a different stored consensus info of the same epoch along with the following epochs
 func (s *Service) onNewConsensusInfo(ctx context.Context, consensusInfo *types.MinimalEpochConsensusInfoV2) error {
+	storedEpochInfo, _ := s.db.ConsensusInfo(ctx, consensusInfo.Epoch)
+	replacing := storedEpochInfo != nil && !sameEpochInfo(storedEpochInfo, consensusInfo)
+	if consensusInfo.ReorgInfo == nil && !replacing && s.isEpochSent(consensusInfo.Epoch) {
+		log.WithField("epoch", consensusInfo.Epoch).Debug("Skipping already forwarded consensus info")
+		vanguardReplayedEvents.WithLabelValues("epoch").Inc()
+		return nil
+	}
+
+	// invalid consensus infos are dropped without stopping the subscription
+	var prevEpochInfo *types.MinimalEpochConsensusInfo
+	if consensusInfo.Epoch > 0 {
+		prevEpochInfo, _ = s.db.ConsensusInfo(ctx, consensusInfo.Epoch-1)
+	}
+	if err := s.validator.validate(consensusInfo, prevEpochInfo, s.db.LatestLatestFinalizedEpoch(), replacing); err != nil {
+		log.WithError(err).WithField("epoch", consensusInfo.Epoch).Warn("Rejected invalid consensus info")
+		rejectedConsensusInfos.WithLabelValues(rejectionReason(err)).Inc()
+		return nil
+	}
+	s.validator.accept(consensusInfo.Epoch)
+
+	if storedEpochInfo != nil {
+		consensusInfo.Version = storedEpochInfo.Version
+	}
+	if replacing {
+		consensusInfo.Version++
+		consensusInfo.Replaced = true
+		if err := s.replaceEpoch(ctx, storedEpochInfo, consensusInfo); err != nil {
+			return err
+		}
+	} else if consensusInfo.ReorgInfo == nil {
+		s.markEpochSent(consensusInfo.Epoch)
+	}
 	nsent := s.consensusInfoFeed.Send(consensusInfo)
 	log.WithField("nsent", nsent).Trace("Send consensus info to subscribers")
 
@@ -25,6 +58,8 @@ func (s *Service) onNewConsensusInfo(ctx context.Context, consensusInfo *types.M
 		log.WithError(err).Warn("failed to save latest epoch into consensusInfoDB!")
 		return err
 	}
+	// only validated and stored epochs are skipped when a broken stream resumes
+	s.advanceEpochCursor(consensusInfo.Epoch)
 
 	if consensusInfo.ReorgInfo != nil {
 		nsent = s.subscriptionShutdownFeed.Send(consensusInfo.ReorgInfo)
@@ -38,34 +73,51 @@ func (s *Service) onNewConsensusInfo(ctx context.Context, consensusInfo *types.M
 // onNewPendingVanguardBlock
 func (s *Service) onNewPendingVanguardBlock(ctx context.Context, blockInfo *eth.StreamPendingBlockInfo) error {
 	block := blockInfo.Block
+	cachedShardInfo, err := newShardInfo(block, uint64(blockInfo.FinalizedSlot), uint64(blockInfo.FinalizedEpoch))
+	if err != nil {
+		return err
+	}
+
+	var blockHash [32]byte
+	copy(blockHash[:], cachedShardInfo.BlockHash)
+	s.advanceBlockCursor(uint64(block.Slot))
+	if !s.markBlockSent(blockHash, uint64(block.Slot), uint64(blockInfo.FinalizedSlot)) {
+		log.WithField("slot", block.Slot).Debug("Skipping already forwarded vanguard block")
+		vanguardReplayedEvents.WithLabelValues("block").Inc()
+		return nil
+	}
+
+	log.WithField("slot", block.Slot).WithField("panBlockNum", cachedShardInfo.ShardInfo.BlockNumber).
+		WithField("finalizedSlot", blockInfo.FinalizedSlot).WithField("finalizedEpoch", blockInfo.FinalizedEpoch).
+		Info("New vanguard shard info has arrived")
+
+	s.updateHeadSlot(cachedShardInfo.Slot)
+	s.vanguardShardingInfoFeed.Send(cachedShardInfo)
+	return nil
+}
+
+// newShardInfo reads the pandora shard info of a vanguard block.
+func newShardInfo(block *eth.BeaconBlock, finalizedSlot, finalizedEpoch uint64) (*types.VanguardShardInfo, error) {
 	blockHash, err := block.HashTreeRoot()
 	if nil != err {
 		log.WithError(err).Warn("failed to retrieve vanguard block hash from HashTreeRoot")
-		return err
+		return nil, err
 	}
 	wrappedPhase0Blk := wrapper.WrappedPhase0BeaconBlock(block)
 	pandoraShards := wrappedPhase0Blk.Body().PandoraShards()
 	if len(pandoraShards) < 1 {
 		// The first value is the sharding info. If not present throw error
 		log.WithField("pandoraShard length", len(pandoraShards)).Error("pandora sharding info not present")
-		return errors.New("invalid shard info length in vanguard block body")
+		return nil, errors.New("invalid shard info length in vanguard block body")
 	}
 
-	shardInfo := pandoraShards[0]
-	cachedShardInfo := &types.VanguardShardInfo{
+	return &types.VanguardShardInfo{
 		Slot:           uint64(block.Slot),
 		BlockHash:      blockHash[:],
-		ShardInfo:      shardInfo,
-		FinalizedSlot:  uint64(blockInfo.FinalizedSlot),
-		FinalizedEpoch: uint64(blockInfo.FinalizedEpoch),
-	}
-
-	log.WithField("slot", block.Slot).WithField("panBlockNum", shardInfo.BlockNumber).
-		WithField("finalizedSlot", blockInfo.FinalizedSlot).WithField("finalizedEpoch", blockInfo.FinalizedEpoch).
-		Info("New vanguard shard info has arrived")
-
-	s.vanguardShardingInfoFeed.Send(cachedShardInfo)
-	return nil
+		ShardInfo:      pandoraShards[0],
+		FinalizedSlot:  finalizedSlot,
+		FinalizedEpoch: finalizedEpoch,
+	}, nil
 }
 
 // ReSubscribeBlocksEvent method re-subscribe to vanguard block api.
@@ -85,6 +137,8 @@ func (s *Service) ReSubscribeBlocksEvent() error {
 		return err
 	}
 
+	vanguardReconnects.Inc()
+	s.resetSentEvents()
 	// Re-subscribe vanguard new pending blocks
 	go s.subscribeVanNewPendingBlockHash(s.ctx, finalizedSlot)
 	go s.subscribeNewConsensusInfoGRPC(s.ctx, finalizedEpoch)
@@ -93,8 +147,119 @@ func (s *Service) ReSubscribeBlocksEvent() error {
 
 func (s *Service) StopSubscription() {
 	defer log.Info("Stopped vanguard gRPC subscription")
+	s.processingLock.Lock()
 	if s.conn != nil {
 		s.conn.Close()
 		s.conn = nil
 	}
+	s.processingLock.Unlock()
+	s.setConnected(false)
+}
+
+// PauseSubscription stops vanguard subscriptions until ResumeSubscription is called.
+func (s *Service) PauseSubscription() {
+	s.processingLock.Lock()
+	s.paused = true
+	s.subscriptionGen++
+	s.processingLock.Unlock()
+
+	s.StopSubscription()
+	log.Info("Paused vanguard subscription")
+}
+
+// ResumeSubscription re-subscribes a paused vanguard subscription from the latest finalized slot and epoch.
+func (s *Service) ResumeSubscription() error {
+	s.processingLock.Lock()
+	paused := s.paused
+	s.processingLock.Unlock()
+	if !paused {
+		return errSubscriptionNotPaused
+	}
+	return s.ResubscribeFrom(s.db.LatestLatestFinalizedSlot(), s.db.LatestLatestFinalizedEpoch())
+}
+
+// ResubscribeFrom replaces the running vanguard subscriptions by new ones which start
+// streaming blocks from the given slot and consensus infos from the given epoch.
+func (s *Service) ResubscribeFrom(fromSlot, fromEpoch uint64) error {
+	s.processingLock.Lock()
+	s.paused = false
+	s.subscriptionGen++
+	s.processingLock.Unlock()
+
+	s.StopSubscription()
+	if err := s.connect(); err != nil {
+		log.WithError(err).Error("Could not reach vanguard node during re-subscription")
+		return err
+	}
+	vanguardReconnects.Inc()
+	// explicitly requested events are forwarded again
+	s.resetSentEvents()
+
+	log.WithField("fromSlot", fromSlot).WithField("fromEpoch", fromEpoch).Info("Re-subscribing to vanguard")
+	go s.subscribeVanNewPendingBlockHash(s.ctx, fromSlot)
+	go s.subscribeNewConsensusInfoGRPC(s.ctx, fromEpoch)
+	return nil
+}
+
+// markBlockSent records a forwarded block and returns false if it was already forwarded. Blocks below
+// the finalized slot are forgotten as they are never streamed again.
+func (s *Service) markBlockSent(blockRoot [32]byte, slot, finalizedSlot uint64) bool {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+
+	if _, ok := s.sentBlockRoots[blockRoot]; ok {
+		return false
+	}
+	for root, sentSlot := range s.sentBlockRoots {
+		if sentSlot < finalizedSlot {
+			delete(s.sentBlockRoots, root)
+		}
+	}
+	s.sentBlockRoots[blockRoot] = slot
+	return true
+}
+
+// markEpochSent records a forwarded epoch and returns false if it was already forwarded.
+func (s *Service) markEpochSent(epoch uint64) bool {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+
+	if s.lastSentEpoch != nil && epoch <= *s.lastSentEpoch {
+		return false
+	}
+	s.lastSentEpoch = &epoch
+	return true
+}
+
+// isEpochSent returns true if the epoch, or a later one, was already forwarded.
+func (s *Service) isEpochSent(epoch uint64) bool {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	return s.lastSentEpoch != nil && epoch <= *s.lastSentEpoch
+}
+
+// resetSentEvents forgets the forwarded events and the stream cursors, so the next subscriptions forward
+// everything they receive.
+func (s *Service) resetSentEvents() {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+
+	s.sentBlockRoots = make(map[[32]byte]uint64)
+	s.lastSentEpoch = nil
+	s.cursor = streamCursor{}
+	s.validator.reset()
+}
+
+// subscriptionGeneration returns the generation of the currently running subscriptions.
+func (s *Service) subscriptionGeneration() uint64 {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	return s.subscriptionGen
+}
+
+// isStaleSubscription returns true when a subscription of the given generation must not reconnect anymore.
+func (s *Service) isStaleSubscription(generation uint64) bool {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	return s.paused || generation != s.subscriptionGen
 }
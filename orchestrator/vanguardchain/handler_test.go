@@ -1 +1,20 @@
 package vanguardchain
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func TestService_PauseSubscription(t *testing.T) {
+	s, _ := serviceInit(t, 5)
+	generation := s.subscriptionGeneration()
+	assert.Equal(t, false, s.isStaleSubscription(generation))
+	assert.ErrorContains(t, errSubscriptionNotPaused.Error(), s.ResumeSubscription())
+
+	s.setConnected(true)
+	s.PauseSubscription()
+	assert.Equal(t, true, s.isStaleSubscription(generation))
+	assert.Equal(t, true, s.isStaleSubscription(s.subscriptionGeneration()))
+	assert.Equal(t, false, s.IsConnected())
+}
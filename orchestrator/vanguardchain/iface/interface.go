@@ -1,6 +1,8 @@
 package iface
 
 import (
+	"context"
+
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 )
@@ -14,4 +16,9 @@ type VanguardService interface {
 	SubscribeShutdownSignalEvent(chan<- *types.Reorg) event.Subscription
 	ReSubscribeBlocksEvent() error
 	StopSubscription()
+	IsConnected() bool
+	HeadSlot() uint64
+	BlockBySlot(ctx context.Context, slot uint64) (*types.VanguardShardInfo, error)
+	ChainHead() *types.VanguardChainHead
+	SubscribeChainHeadEvent(chan<- *types.VanguardChainHead) event.Subscription
 }
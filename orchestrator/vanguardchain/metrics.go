@@ -0,0 +1,45 @@
+package vanguardchain
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	vanguardConnected = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "orchestrator_vanguard_connected",
+		Help: "Boolean indicating whether the orchestrator is connected to the vanguard node",
+	})
+	vanguardReconnects = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "orchestrator_vanguard_reconnects_total",
+		Help: "Number of times the orchestrator re-established its subscriptions with the vanguard node",
+	})
+	vanguardFailovers = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "orchestrator_vanguard_failovers_total",
+		Help: "Number of times the orchestrator switched to another vanguard endpoint",
+	})
+	vanguardEndpointHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "orchestrator_vanguard_endpoint_healthy",
+		Help: "Boolean indicating whether a vanguard endpoint passed its latest health check",
+	}, []string{"endpoint"})
+	vanguardReplayedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "orchestrator_vanguard_replayed_events_total",
+		Help: "Number of blocks and consensus infos streamed again by vanguard and dropped as duplicates",
+	}, []string{"kind"})
+	rejectedConsensusInfos = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "orchestrator_vanguard_rejected_consensus_infos_total",
+		Help: "Number of invalid consensus infos dropped, partitioned by the failed check",
+	}, []string{"reason"})
+	replacedEpochs = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "orchestrator_vanguard_replaced_epochs_total",
+		Help: "Number of stored consensus infos replaced by a different consensus info of the same epoch",
+	})
+	vanguardJustifiedEpoch = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "orchestrator_vanguard_justified_epoch",
+		Help: "Latest justified epoch reported by the vanguard node",
+	})
+	vanguardFinalizedEpoch = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "orchestrator_vanguard_finalized_epoch",
+		Help: "Latest finalized epoch reported by the vanguard node",
+	})
+)
@@ -0,0 +1,48 @@
+package vanguardchain
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var errNetworkMismatch = errors.New("vanguard node belongs to another network than the orchestrator database")
+
+// verifyNetwork checks the genesis of the connected vanguard node against the one pinned in the database.
+// The network of the first connected node is pinned. A node of another network is refused, the orchestrator
+// must not mix the data of several networks.
+func (s *Service) verifyNetwork(endpoint string) error {
+	s.processingLock.RLock()
+	client := s.nodeClient
+	s.processingLock.RUnlock()
+
+	ctx, cancel := context.WithTimeout(s.ctx, healthCheckTimeout)
+	defer cancel()
+	genesis, err := client.GetGenesis(ctx, &emptypb.Empty{})
+	if err != nil {
+		return errors.Wrap(err, "could not get vanguard genesis")
+	}
+	s.recorder.RecordVanguardGenesis(genesis)
+	network := &types.VanguardNetwork{
+		GenesisTime:           uint64(genesis.GenesisTime.GetSeconds()),
+		GenesisValidatorsRoot: common.BytesToHash(genesis.GenesisValidatorsRoot),
+		DepositContract:       common.BytesToAddress(genesis.DepositContractAddress),
+	}
+
+	pinned, err := s.db.PinVanguardNetwork(network)
+	if err != nil {
+		return err
+	}
+	if *pinned != *network {
+		return errors.Wrapf(errNetworkMismatch,
+			"node %s has genesis time %d and genesis validators root %s, database was built for genesis time %d "+
+				"and genesis validators root %s (remove the database with --clear-db to switch networks)",
+			endpoint, network.GenesisTime, network.GenesisValidatorsRoot, pinned.GenesisTime, pinned.GenesisValidatorsRoot)
+	}
+	log.WithField("genesisTime", network.GenesisTime).WithField("genesisValidatorsRoot", network.GenesisValidatorsRoot).
+		Debug("Verified vanguard network")
+	return nil
+}
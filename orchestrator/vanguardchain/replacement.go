@@ -0,0 +1,55 @@
+package vanguardchain
+
+import (
+	"context"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// sameEpochInfo returns true when the consensus info describes the same epoch as the stored one.
+func sameEpochInfo(stored *types.MinimalEpochConsensusInfo, info *types.MinimalEpochConsensusInfoV2) bool {
+	if stored.EpochStartTime != info.EpochStartTime || stored.SlotTimeDuration != info.SlotTimeDuration ||
+		len(stored.ValidatorList) != len(info.ValidatorList) {
+		return false
+	}
+	for i, pubKey := range stored.ValidatorList {
+		if pubKey != info.ValidatorList[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// replaceEpoch removes the epochs following a replaced epoch, as they were derived from the replaced one,
+// and rewinds the forwarded epochs so the following epochs are forwarded again once vanguard sends them.
+func (s *Service) replaceEpoch(ctx context.Context, stored *types.MinimalEpochConsensusInfo, info *types.MinimalEpochConsensusInfoV2) error {
+	latestEpoch := s.db.LatestSavedEpoch()
+	if latestEpoch > info.Epoch {
+		if err := s.db.RemoveRangeConsensusInfo(info.Epoch+1, latestEpoch); err != nil {
+			log.WithError(err).Warn("failed to remove epochs following the replaced epoch from consensusInfoDB!")
+			return err
+		}
+	}
+	s.rewindSentEpoch(info.Epoch)
+
+	replacedEpochs.Inc()
+	log.WithField("epoch", info.Epoch).WithField("version", info.Version).
+		WithField("removedEpochs", saturatingSub(latestEpoch, info.Epoch)).
+		WithField("oldEpochStartTime", stored.EpochStartTime).WithField("newEpochStartTime", info.EpochStartTime).
+		Warn("Replacing stored consensus info of an epoch")
+	return nil
+}
+
+// rewindSentEpoch makes the given epoch the latest forwarded one, even if later epochs were forwarded already.
+func (s *Service) rewindSentEpoch(epoch uint64) {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	s.lastSentEpoch = &epoch
+}
+
+func saturatingSub(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
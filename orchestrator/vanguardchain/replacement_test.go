@@ -0,0 +1,60 @@
+package vanguardchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_ReplacesStoredEpoch(t *testing.T) {
+	ctx := context.Background()
+	s, _ := serviceInit(t, 5)
+	consensusInfoCh := make(chan *types.MinimalEpochConsensusInfoV2, 10)
+	sub := s.SubscribeMinConsensusInfoEvent(consensusInfoCh)
+	defer sub.Unsubscribe()
+	prev, err := s.db.ConsensusInfo(ctx, 1)
+	require.NoError(t, err)
+
+	// a different consensus info of a stored epoch replaces it along with the following epochs
+	replacement := testutil.NewMinimalConsensusInfo(2)
+	replacement.EpochStartTime = prev.EpochStartTime + types.SlotsPerEpoch*uint64(prev.SlotTimeDuration)
+	require.NoError(t, s.onNewConsensusInfo(ctx, replacement))
+	require.Equal(t, 1, len(consensusInfoCh))
+	notified := <-consensusInfoCh
+	assert.Equal(t, true, notified.Replaced)
+	assert.Equal(t, uint64(1), notified.Version)
+
+	stored, err := s.db.ConsensusInfo(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, replacement.EpochStartTime, stored.EpochStartTime)
+	assert.Equal(t, uint64(1), stored.Version)
+	assert.Equal(t, uint64(2), s.db.LatestSavedEpoch())
+	removed, err := s.db.ConsensusInfo(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.MinimalEpochConsensusInfo)(nil), removed)
+
+	// the same consensus info is not replaced again, neither by an invalid one
+	replayed := testutil.NewMinimalConsensusInfo(2)
+	replayed.EpochStartTime = replacement.EpochStartTime
+	require.NoError(t, s.onNewConsensusInfo(ctx, replayed))
+	require.NoError(t, s.onNewConsensusInfo(ctx, testutil.NewMinimalConsensusInfo(2)))
+	assert.Equal(t, 0, len(consensusInfoCh))
+
+	// the following epochs are forwarded again
+	next := testutil.NewMinimalConsensusInfo(3)
+	next.EpochStartTime = replacement.EpochStartTime + types.SlotsPerEpoch*uint64(replacement.SlotTimeDuration)
+	require.NoError(t, s.onNewConsensusInfo(ctx, next))
+	require.Equal(t, 1, len(consensusInfoCh))
+	assert.Equal(t, false, (<-consensusInfoCh).Replaced)
+	assert.Equal(t, uint64(3), s.db.LatestSavedEpoch())
+
+	// finalized epochs are never replaced
+	finalized := testutil.NewMinimalConsensusInfo(0)
+	finalized.EpochStartTime++
+	require.NoError(t, s.onNewConsensusInfo(ctx, finalized))
+	assert.Equal(t, 0, len(consensusInfoCh))
+}
@@ -0,0 +1,76 @@
+package vanguardchain
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	"github.com/lukso-network/lukso-orchestrator/shared/replay"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+)
+
+// recordBlocks records the blocks of the given slots and returns the path of the recording.
+func recordBlocks(t *testing.T, slots ...uint64) string {
+	path := filepath.Join(t.TempDir(), "recorded.jsonl")
+	recorder, err := replay.NewRecorder(path)
+	require.NoError(t, err)
+	recorder.RecordVanguardGenesis(&ethpb.Genesis{
+		GenesisTime:           &timestamp.Timestamp{Seconds: 1624266000},
+		GenesisValidatorsRoot: make([]byte, 32),
+	})
+	for _, slot := range slots {
+		block := testutil.NewBeaconBlock(slot)
+		block.Body.PandoraShard[0].SealHash = make([]byte, 32)
+		recorder.RecordVanguardBlock(&ethpb.StreamPendingBlockInfo{Block: block, FinalizedSlot: 32, FinalizedEpoch: 1})
+	}
+	require.NoError(t, recorder.Stop())
+	return path
+}
+
+func TestService_ReplayedStreams(t *testing.T) {
+	records, err := replay.ReadRecords(recordBlocks(t, 33, 34, 35, 36))
+	require.NoError(t, err)
+	server, err := replay.NewServer(&replay.Config{Records: records, Faults: replay.Faults{DisconnectAfter: 2}})
+	require.NoError(t, err)
+	defer server.Stop()
+	endpoint, err := server.ServeGRPC("127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	s, err := NewService(ctx, []string{endpoint}, nil, dbSetup(ctx, t, 5), cache.NewVanShardInfoCache(1024), nil)
+	require.NoError(t, err)
+	recorder, err := replay.NewRecorder(filepath.Join(t.TempDir(), "rerecorded.jsonl"))
+	require.NoError(t, err)
+	s.SetRecorder(recorder)
+	shardInfoCh := make(chan *types.VanguardShardInfo, 10)
+	sub := s.SubscribeShardInfoEvent(shardInfoCh)
+	defer sub.Unsubscribe()
+
+	server.Start()
+	s.Start()
+	defer s.Stop()
+
+	// the broken stream is resumed and every block is forwarded once
+	var slots []uint64
+	for len(slots) < 4 {
+		select {
+		case shardInfo := <-shardInfoCh:
+			slots = append(slots, shardInfo.Slot)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Received slots %v", slots)
+		}
+	}
+	assert.DeepEqual(t, []uint64{33, 34, 35, 36}, slots)
+	assert.Equal(t, uint64(36), s.HeadSlot())
+
+	// the re-subscription overlapping the broken stream is recorded as received
+	require.NoError(t, recorder.Stop())
+	assert.Equal(t, true, recorder.Records() > 5, "recorded %d messages", recorder.Records())
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
 	"net"
 	"net/url"
 	"strings"
@@ -12,27 +11,22 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/event"
-	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/backoff"
+	"github.com/lukso-network/lukso-orchestrator/shared/replay"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-// time to wait before trying to reconnect with the vanguard node.
-var (
-	reConPeriod = 2 * time.Second
-	errDialNil  = errors.New("failed to construct dial options")
-)
+var errSubscriptionNotPaused = errors.New("vanguard subscription is not paused")
 
 // Service
-// 	- maintains connection with vanguard chain
-//	- handles vanguard subscription for consensus info.
-//  - sends new consensus info to all pandora subscribers.
-//  - maintains consensusInfoDB to store the coming consensus info from vanguard.
+//   - maintains connection with vanguard chain
+//   - handles vanguard subscription for consensus info.
+//   - sends new consensus info to all pandora subscribers.
+//   - maintains consensusInfoDB to store the coming consensus info from vanguard.
 type Service struct {
 	// service maintenance related attributes
 	isRunning      bool
@@ -43,17 +37,34 @@ type Service struct {
 
 	// vanguard chain related attributes
 	connectedVanguard bool
-	vanGRPCEndpoint   string
+	headSlot          uint64                   // vanguard head slot, as reported by the node or streamed since
+	chainHead         *types.VanguardChainHead // latest polled head and checkpoints
+	paused            bool                     // paused subscriptions are not re-established automatically
+	subscriptionGen   uint64                   // bumped whenever running subscriptions must give up reconnecting
+	connectionGen     uint64                   // bumped whenever a (re)connection with a vanguard endpoint is established
+	reconnectLock     sync.Mutex
+	backoff           *backoff.Backoff // delays the reconnection attempts
+	endpoints         []string         // all configured vanguard endpoints
+	mismatched        map[string]bool  // endpoints of another network, never connected to again
+	vanGRPCEndpoint   string           // endpoint of the active connection
 	dialOpts          []grpc.DialOption
 	beaconClient      ethpb.BeaconChainClient
 	nodeClient        ethpb.NodeClient
 	conn              *grpc.ClientConn
 
+	// already forwarded events which must not be sent again after a failover
+	sentBlockRoots map[[32]byte]uint64
+	lastSentEpoch  *uint64
+	cursor         streamCursor // position broken streams resume from
+	validator      *consensusInfoValidator
+	recorder       *replay.Recorder // records the raw streams when enabled
+
 	// subscription
 	consensusInfoFeed        event.Feed
 	scope                    event.SubscriptionScope
 	vanguardShardingInfoFeed event.Feed
 	subscriptionShutdownFeed event.Feed
+	chainHeadFeed            event.Feed
 
 	db                  db.Database              // db support
 	shardingInfoCache   cache.VanguardShardCache // lru cache support
@@ -61,21 +72,43 @@ type Service struct {
 	stopEpochInfoSubCh  chan struct{}
 }
 
-// NewService creates new service with vanguard endpoint, vanguard namespace and consensusInfoDB
+// NewService creates new service with vanguard endpoints, grpc dial config and consensusInfoDB.
+// A nil dial config dials an insecure connection with the default options. The service connects to the
+// healthiest of the given endpoints and fails over to another one when the connection breaks, backing
+// off between the attempts as configured by backoffCfg.
 func NewService(
 	ctx context.Context,
-	vanGRPCEndpoint string,
+	vanGRPCEndpoints []string,
+	dialConfig *DialConfig,
 	db db.Database,
 	cache cache.VanguardShardCache,
+	backoffCfg *backoff.Config,
 ) (*Service, error) {
 
 	ctx, cancel := context.WithCancel(ctx)
 	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
 
+	if dialConfig == nil {
+		dialConfig = DefaultDialConfig()
+	}
+	dialOpts, err := constructDialOptions(dialConfig)
+	if err != nil {
+		return nil, err
+	}
+	endpoints := dedupeEndpoints(vanGRPCEndpoints)
+	var vanGRPCEndpoint string
+	if len(endpoints) > 0 {
+		vanGRPCEndpoint = endpoints[0]
+	}
 	return &Service{
 		ctx:                 ctx,
 		cancel:              cancel,
+		endpoints:           endpoints,
 		vanGRPCEndpoint:     vanGRPCEndpoint,
+		dialOpts:            dialOpts,
+		backoff:             backoff.New("vanguard", backoffCfg),
+		sentBlockRoots:      make(map[[32]byte]uint64),
+		validator:           newConsensusInfoValidator(types.SlotsPerEpoch),
 		db:                  db,
 		shardingInfoCache:   cache,
 		stopPendingBlkSubCh: make(chan struct{}),
@@ -86,16 +119,18 @@ func NewService(
 // Start a consensus info fetcher service's main event loop.
 func (s *Service) Start() {
 	// Exit early if endpoint is not set.
-	if s.vanGRPCEndpoint == "" {
+	if len(s.endpoints) == 0 {
 		log.Error("Missing vanguard node's endpoint")
 		return
 	}
 
+	s.restoreChainHead()
 	if err := s.dialConn(); err != nil {
 		log.WithError(err).Error("Could not create connection with vanguard node")
 		return
 	}
 
+	s.isRunning = true
 	go s.run()
 }
 
@@ -104,6 +139,7 @@ func (s *Service) Stop() error {
 		defer s.cancel()
 	}
 	s.scope.Close()
+	s.backoff.Close()
 	if s.conn != nil {
 		s.conn.Close()
 	}
@@ -124,8 +160,9 @@ func (s *Service) Status() error {
 
 // run subscribes to all the services for the ETH1.0 chain.
 func (s *Service) run() {
-
-	s.waitForConnection()
+	if err := s.waitForConnection(); err != nil {
+		return
+	}
 
 	latestFinalizedEpoch := s.db.LatestLatestFinalizedEpoch()
 	latestFinalizedSlot := s.db.LatestLatestFinalizedSlot()
@@ -148,43 +185,89 @@ func (s *Service) run() {
 
 	go s.subscribeNewConsensusInfoGRPC(s.ctx, fromEpoch)
 	go s.subscribeVanNewPendingBlockHash(s.ctx, latestFinalizedSlot)
+	go s.pollChainHead(s.ctx)
 }
 
-// waitForConnection waits for a connection with vanguard chain. Until a successful with
-// vanguard chain, it retries again and again.
-func (s *Service) waitForConnection() {
-	if err := s.dialConn(); err != nil {
-		log.WithError(err).Error("Could not create connection with vanguard node during re-subscription")
-		return
+// waitForConnection waits for a connection with vanguard chain. Until a successful connection with
+// one of the vanguard endpoints, it retries with a growing delay. Once the configured number of attempts
+// failed or all the nodes belong to another network, it gives up and the service reports itself unhealthy.
+func (s *Service) waitForConnection() error {
+	err := s.backoff.Retry(s.ctx, func() error {
+		err := s.connect()
+		if err != nil {
+			log.WithError(err).Warn("Could not connect to vanguard chain")
+		}
+		return err
+	})
+	switch {
+	case err == nil:
+		s.runError = nil
+	case s.ctx.Err() != nil:
+		log.Info("Received cancelled context, closing existing go routine: waitForConnection")
+	case errors.Is(err, errNetworkMismatch):
+		log.WithError(err).Error("Refusing to proceed with a vanguard node of another network")
+		s.runError = err
+	default:
+		log.WithError(err).Error("Gave up connecting to vanguard chain")
+		s.runError = err
 	}
+	return err
+}
+
+// SubscribeReconnectEvent registers a subscription of the attempts to reconnect with the vanguard node.
+func (s *Service) SubscribeReconnectEvent(ch chan<- *backoff.Event) event.Subscription {
+	return s.backoff.SubscribeEvent(ch)
+}
 
-	if _, err := s.beaconClient.GetChainHead(s.ctx, &emptypb.Empty{}); err == nil {
-		log.WithField("vanguardEndpoint", s.vanGRPCEndpoint).Info("Connected vanguard chain")
-		s.connectedVanguard = true
+// setConnected updates the vanguard connection state along with its metric.
+func (s *Service) setConnected(connected bool) {
+	s.processingLock.Lock()
+	s.connectedVanguard = connected
+	s.processingLock.Unlock()
+
+	if connected {
+		vanguardConnected.Set(1)
 		return
 	}
+	vanguardConnected.Set(0)
+}
 
-	ticker := time.NewTicker(reConPeriod)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			if _, err := s.beaconClient.GetChainHead(s.ctx, &emptypb.Empty{}); err != nil {
-				log.WithField("vanguardEndpoint", s.vanGRPCEndpoint).Warn("Could not connect or subscribe to vanguard chain")
-				continue
-			}
-			s.connectedVanguard = true
-			s.runError = nil
-			log.WithField("vanguardEndpoint", s.vanGRPCEndpoint).Info("Connected vanguard chain")
-			return
-		case <-s.ctx.Done():
-			log.Info("Received cancelled context, closing existing go routine: waitForConnection")
-			return
-		}
+// IsConnected returns true when the orchestrator is connected to the vanguard node.
+func (s *Service) IsConnected() bool {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	return s.connectedVanguard
+}
+
+// updateHeadSlot keeps track of the highest vanguard slot streamed since the head was last reported.
+func (s *Service) updateHeadSlot(slot uint64) {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	if slot > s.headSlot {
+		s.headSlot = slot
 	}
 }
 
+// setHeadSlot takes over the head slot reported by the vanguard node, which is lower than the
+// streamed one after the chain rolled back.
+func (s *Service) setHeadSlot(slot uint64) {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	s.headSlot = slot
+}
+
+// HeadSlot returns the highest vanguard slot known by the orchestrator.
+func (s *Service) HeadSlot() uint64 {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	return s.headSlot
+}
+
+// SetRecorder records the raw messages received from the vanguard nodes. It must be called before Start.
+func (s *Service) SetRecorder(recorder *replay.Recorder) {
+	s.recorder = recorder
+}
+
 // SubscribeMinConsensusInfoEvent registers a subscription of ChainHeadEvent.
 func (s *Service) SubscribeMinConsensusInfoEvent(ch chan<- *types.MinimalEpochConsensusInfoV2) event.Subscription {
 	return s.scope.Track(s.consensusInfoFeed.Subscribe(ch))
@@ -207,25 +290,7 @@ func (s *Service) dialConn() error {
 		return nil
 	}
 
-	grpcAddress, protocol, err := resolveRpcAddressAndProtocol(s.vanGRPCEndpoint, "")
-	if nil != err {
-		return nil
-	}
-
-	dialOpts := constructDialOptions(math.MaxInt32, "", 32, time.Minute*6)
-	if dialOpts == nil {
-		return errDialNil
-	}
-
-	if "unix" == protocol {
-		dialer := func(addr string, t time.Duration) (net.Conn, error) {
-			return net.Dial(protocol, addr)
-		}
-
-		dialOpts = append(dialOpts, grpc.WithDialer(dialer))
-	}
-
-	c, err := grpc.DialContext(s.ctx, grpcAddress, dialOpts...)
+	c, err := s.dialEndpoint(s.vanGRPCEndpoint)
 	if err != nil {
 		return err
 	}
@@ -237,44 +302,23 @@ func (s *Service) dialConn() error {
 	return nil
 }
 
-// constructDialOptions constructs a list of grpc dial options
-func constructDialOptions(
-	maxCallRecvMsgSize int,
-	withCert string,
-	grpcRetries uint,
-	grpcRetryDelay time.Duration,
-	extraOpts ...grpc.DialOption,
-) []grpc.DialOption {
-	var transportSecurity grpc.DialOption
-	if withCert != "" {
-		creds, err := credentials.NewClientTLSFromFile(withCert, "")
-		if err != nil {
-			log.Errorf("Could not get valid credentials: %v", err)
-			return nil
-		}
-		transportSecurity = grpc.WithTransportCredentials(creds)
-	} else {
-		transportSecurity = grpc.WithInsecure()
-		log.Warn("You are using an insecure gRPC connection. If you are running your beacon node and " +
-			"validator on the same machines, you can ignore this message. If you want to know " +
-			"how to enable secure connections, see: https://docs.prylabs.network/docs/prysm-usage/secure-grpc")
+// dialEndpoint creates a grpc connection with the given vanguard endpoint
+func (s *Service) dialEndpoint(endpoint string) (*grpc.ClientConn, error) {
+	grpcAddress, protocol, err := resolveRpcAddressAndProtocol(endpoint, "")
+	if nil != err {
+		return nil, err
 	}
 
-	if maxCallRecvMsgSize == 0 {
-		maxCallRecvMsgSize = 10 * 5 << 20 // Default 50Mb
-	}
+	dialOpts := append([]grpc.DialOption{}, s.dialOpts...)
+	if "unix" == protocol {
+		dialer := func(addr string, t time.Duration) (net.Conn, error) {
+			return net.Dial(protocol, addr)
+		}
 
-	dialOpts := []grpc.DialOption{
-		transportSecurity,
-		grpc.WithDefaultCallOptions(
-			grpc.MaxCallRecvMsgSize(maxCallRecvMsgSize),
-			grpc_retry.WithMax(grpcRetries),
-			grpc_retry.WithBackoff(grpc_retry.BackoffLinear(grpcRetryDelay)),
-		),
+		dialOpts = append(dialOpts, grpc.WithDialer(dialer))
 	}
 
-	dialOpts = append(dialOpts, extraOpts...)
-	return dialOpts
+	return grpc.DialContext(s.ctx, grpcAddress, dialOpts...)
 }
 
 // resolveRpcAddressAndProtocol returns a RPC address and protocol.
@@ -22,8 +22,10 @@ var (
 
 // subscribeVanNewPendingBlockHash
 func (s *Service) subscribeVanNewPendingBlockHash(ctx context.Context, fromSlot uint64) error {
+	generation := s.subscriptionGeneration()
+	connGeneration := s.connectionGeneration()
 	var blockRoot []byte
-	stream, err := s.beaconClient.StreamNewPendingBlocks(ctx,
+	stream, err := s.activeBeaconClient().StreamNewPendingBlocks(ctx,
 		&ethpb.StreamPendingBlocksRequest{
 			BlockRoot: blockRoot,
 			FromSlot:  eth2Types.Slot(fromSlot),
@@ -49,20 +51,28 @@ func (s *Service) subscribeVanNewPendingBlockHash(ctx context.Context, fromSlot
 				if e, ok := status.FromError(err); ok {
 					switch e.Code() {
 					case codes.Canceled, codes.Internal, codes.Unavailable:
+						if s.isStaleSubscription(generation) {
+							log.Info("Vanguard subscription is paused or replaced, exiting pending block streaming subscription")
+							return nil
+						}
 						log.WithError(err).Infof("Trying to restart connection. rpc status: %v", e.Code())
-						s.waitForConnection()
-						// Re-try subscription from latest finalized slot
-						latestFinalizedSlot := s.db.LatestLatestFinalizedSlot()
-						stream, err = s.beaconClient.StreamNewPendingBlocks(ctx,
+						if err := s.reconnect(connGeneration); err != nil {
+							log.WithError(err).Error("Could not reconnect to vanguard, exiting pending block streaming subscription")
+							return err
+						}
+						connGeneration = s.connectionGeneration()
+						// Re-try subscription shortly before the cursor, already forwarded blocks are skipped
+						resumeSlot := s.blockResumeSlot()
+						stream, err = s.activeBeaconClient().StreamNewPendingBlocks(ctx,
 							&ethpb.StreamPendingBlocksRequest{
 								BlockRoot: blockRoot,
-								FromSlot:  eth2Types.Slot(latestFinalizedSlot),
+								FromSlot:  eth2Types.Slot(resumeSlot),
 							})
 						if err != nil {
 							log.WithError(err).Error("Failed to subscribe to new pending blocks stream")
 							return err
 						}
-						log.WithField("finalizedSlot", latestFinalizedSlot).Info("Successfully re-subscribed to vanguard blocks")
+						log.WithField("fromSlot", resumeSlot).Info("Successfully re-subscribed to vanguard blocks")
 						continue
 					}
 				} else {
@@ -75,6 +85,7 @@ func (s *Service) subscribeVanNewPendingBlockHash(ctx context.Context, fromSlot
 				log.Error("Received nil blockInfo, Exiting go routine")
 				return errBlockInfoNil
 			}
+			s.recorder.RecordVanguardBlock(vanBlockInfo)
 
 			if err := s.onNewPendingVanguardBlock(ctx, vanBlockInfo); err != nil {
 				log.WithError(err).Error("Failed to process the pending vanguard shardInfo. Exiting vanguard pending header subscription")
@@ -87,7 +98,9 @@ func (s *Service) subscribeVanNewPendingBlockHash(ctx context.Context, fromSlot
 
 // subscribeNewConsensusInfoGRPC
 func (s *Service) subscribeNewConsensusInfoGRPC(ctx context.Context, fromEpoch uint64) error {
-	stream, err := s.beaconClient.StreamMinimalConsensusInfo(ctx, &ethpb.MinimalConsensusInfoRequest{FromEpoch: eth2Types.Epoch(fromEpoch)})
+	generation := s.subscriptionGeneration()
+	connGeneration := s.connectionGeneration()
+	stream, err := s.activeBeaconClient().StreamMinimalConsensusInfo(ctx, &ethpb.MinimalConsensusInfoRequest{FromEpoch: eth2Types.Epoch(fromEpoch)})
 	if nil != err {
 		log.WithError(err).Error("Failed to subscribe to stream of new consensus info")
 		return err
@@ -111,15 +124,24 @@ func (s *Service) subscribeNewConsensusInfoGRPC(ctx context.Context, fromEpoch u
 				if e, ok := status.FromError(err); ok {
 					switch e.Code() {
 					case codes.Canceled, codes.Internal, codes.Unavailable:
+						if s.isStaleSubscription(generation) {
+							log.Info("Vanguard subscription is paused or replaced, exiting consensus info streaming subscription")
+							return nil
+						}
 						log.WithError(err).Infof("Trying to restart connection. rpc status: %v", e.Code())
-						s.waitForConnection()
-						latestFinalizedEpoch := s.db.LatestLatestFinalizedEpoch()
-						stream, err = s.beaconClient.StreamMinimalConsensusInfo(ctx, &ethpb.MinimalConsensusInfoRequest{FromEpoch: eth2Types.Epoch(latestFinalizedEpoch)})
+						if err := s.reconnect(connGeneration); err != nil {
+							log.WithError(err).Error("Could not reconnect to vanguard, exiting consensus info streaming subscription")
+							return err
+						}
+						connGeneration = s.connectionGeneration()
+						// Re-try subscription shortly before the cursor, already forwarded epochs are skipped
+						resumeEpoch := s.epochResumeEpoch()
+						stream, err = s.activeBeaconClient().StreamMinimalConsensusInfo(ctx, &ethpb.MinimalConsensusInfoRequest{FromEpoch: eth2Types.Epoch(resumeEpoch)})
 						if nil != err {
 							log.WithError(err).Error("Failed to subscribe to stream of new consensus info, Exiting go routine")
 							return err
 						}
-						log.WithField("finalizedEpoch", latestFinalizedEpoch).Info("Successfully re-subscribed to vanguard epoch infos")
+						log.WithField("fromEpoch", resumeEpoch).Info("Successfully re-subscribed to vanguard epoch infos")
 						continue
 					}
 				} else {
@@ -132,13 +154,7 @@ func (s *Service) subscribeNewConsensusInfoGRPC(ctx context.Context, fromEpoch u
 				log.Error("Received nil consensus info, Exiting go routine")
 				return errConsensusInfoNil
 			}
-
-			// Only non empty check for now
-			if len(vanMinimalConsensusInfo.ValidatorList) < 1 {
-				log.WithField("epochInfo", fmt.Sprintf("%+v", vanMinimalConsensusInfo)).
-					Error("Incoming consensus info's validator list is invalid, Exiting go routine")
-				return errInvalidValidatorLength
-			}
+			s.recorder.RecordVanguardConsensusInfo(vanMinimalConsensusInfo)
 
 			consensusInfo := &types.MinimalEpochConsensusInfoV2{
 				Epoch:            uint64(vanMinimalConsensusInfo.Epoch),
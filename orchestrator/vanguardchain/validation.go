@@ -0,0 +1,107 @@
+package vanguardchain
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	errEpochNotMonotonic     = errors.New("consensus info epoch does not follow the previous epoch")
+	errReorgBelowFinalized   = errors.New("consensus info reorg or replacement reverts below the finalized epoch")
+	errInvalidPubKey         = errors.New("consensus info contains a malformed validator public key")
+	errInvalidSlotDuration   = errors.New("consensus info slot duration is invalid")
+	errInvalidEpochStartTime = errors.New("consensus info epoch start time does not follow the previous epoch")
+)
+
+// consensusInfoValidator checks incoming consensus infos before they are stored and forwarded.
+type consensusInfoValidator struct {
+	slotsPerEpoch uint64
+	lock          sync.Mutex
+	lastEpoch     *uint64 // latest accepted epoch
+}
+
+func newConsensusInfoValidator(slotsPerEpoch uint64) *consensusInfoValidator {
+	return &consensusInfoValidator{slotsPerEpoch: slotsPerEpoch}
+}
+
+// validate checks that the epoch follows the latest accepted one unless it belongs to a reorg, or replaces
+// a stored epoch, above the finalized epoch, that every slot of the epoch has a well-formed validator public key and that the epoch
+// starts right after the previous epoch, if known.
+func (v *consensusInfoValidator) validate(
+	info *types.MinimalEpochConsensusInfoV2,
+	prev *types.MinimalEpochConsensusInfo,
+	finalizedEpoch uint64,
+	replacing bool,
+) error {
+	v.lock.Lock()
+	lastEpoch := v.lastEpoch
+	v.lock.Unlock()
+
+	if info.ReorgInfo != nil || replacing {
+		if info.Epoch < finalizedEpoch {
+			return errors.Wrapf(errReorgBelowFinalized, "epoch %d, finalized epoch %d", info.Epoch, finalizedEpoch)
+		}
+	} else if lastEpoch != nil && info.Epoch <= *lastEpoch {
+		return errors.Wrapf(errEpochNotMonotonic, "epoch %d, previous epoch %d", info.Epoch, *lastEpoch)
+	}
+
+	if uint64(len(info.ValidatorList)) != v.slotsPerEpoch {
+		return errors.Wrapf(errInvalidValidatorLength, "got %d validators, expected %d",
+			len(info.ValidatorList), v.slotsPerEpoch)
+	}
+	for i, pubKey := range info.ValidatorList {
+		decoded, err := hexutil.Decode(pubKey)
+		if err != nil || len(decoded) != types.BLSPubKeySize {
+			return errors.Wrapf(errInvalidPubKey, "slot %d of the epoch: %q", i, pubKey)
+		}
+	}
+
+	if info.SlotTimeDuration <= 0 {
+		return errors.Wrapf(errInvalidSlotDuration, "%d", info.SlotTimeDuration)
+	}
+	if prev != nil && prev.Epoch+1 == info.Epoch {
+		// slot durations are given in seconds
+		expectedStartTime := prev.EpochStartTime + v.slotsPerEpoch*uint64(prev.SlotTimeDuration)
+		if info.EpochStartTime != expectedStartTime {
+			return errors.Wrapf(errInvalidEpochStartTime, "got %d, expected %d", info.EpochStartTime, expectedStartTime)
+		}
+	}
+	return nil
+}
+
+// accept records an epoch which passed the validation.
+func (v *consensusInfoValidator) accept(epoch uint64) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.lastEpoch = &epoch
+}
+
+// reset forgets the latest accepted epoch, i.e. before consensus infos are streamed again from an earlier epoch.
+func (v *consensusInfoValidator) reset() {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.lastEpoch = nil
+}
+
+// rejectionReason returns the metric label of a validation error.
+func rejectionReason(err error) string {
+	switch {
+	case errors.Is(err, errEpochNotMonotonic):
+		return "epoch"
+	case errors.Is(err, errReorgBelowFinalized):
+		return "reorg"
+	case errors.Is(err, errInvalidValidatorLength):
+		return "validatorList"
+	case errors.Is(err, errInvalidPubKey):
+		return "pubKey"
+	case errors.Is(err, errInvalidSlotDuration):
+		return "slotDuration"
+	case errors.Is(err, errInvalidEpochStartTime):
+		return "startTime"
+	default:
+		return "unknown"
+	}
+}
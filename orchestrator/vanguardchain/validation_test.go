@@ -0,0 +1,100 @@
+package vanguardchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+func TestConsensusInfoValidator(t *testing.T) {
+	prev := testutil.NewMinimalConsensusInfo(4).ConvertToEpochInfo()
+	nextEpoch := func() *types.MinimalEpochConsensusInfoV2 {
+		info := testutil.NewMinimalConsensusInfo(5)
+		info.EpochStartTime = prev.EpochStartTime + types.SlotsPerEpoch*uint64(prev.SlotTimeDuration)
+		return info
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(info *types.MinimalEpochConsensusInfoV2)
+		wantErr error
+	}{
+		{
+			name:   "valid",
+			modify: func(info *types.MinimalEpochConsensusInfoV2) {},
+		},
+		{
+			name:    "short validator list",
+			modify:  func(info *types.MinimalEpochConsensusInfoV2) { info.ValidatorList = info.ValidatorList[1:] },
+			wantErr: errInvalidValidatorLength,
+		},
+		{
+			name:    "malformed public key",
+			modify:  func(info *types.MinimalEpochConsensusInfoV2) { info.ValidatorList[3] = "0x1234" },
+			wantErr: errInvalidPubKey,
+		},
+		{
+			name:    "not hex encoded public key",
+			modify:  func(info *types.MinimalEpochConsensusInfoV2) { info.ValidatorList[3] = "validator" },
+			wantErr: errInvalidPubKey,
+		},
+		{
+			name:    "missing slot duration",
+			modify:  func(info *types.MinimalEpochConsensusInfoV2) { info.SlotTimeDuration = 0 },
+			wantErr: errInvalidSlotDuration,
+		},
+		{
+			name:    "inconsistent start time",
+			modify:  func(info *types.MinimalEpochConsensusInfoV2) { info.EpochStartTime++ },
+			wantErr: errInvalidEpochStartTime,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := nextEpoch()
+			tt.modify(info)
+			err := newConsensusInfoValidator(types.SlotsPerEpoch).validate(info, prev, 0, false)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			assert.Equal(t, true, errors.Is(err, tt.wantErr))
+		})
+	}
+
+	// epochs must increase unless a reorg above the finalized epoch happened
+	validator := newConsensusInfoValidator(types.SlotsPerEpoch)
+	validator.accept(5)
+	assert.Equal(t, true, errors.Is(validator.validate(nextEpoch(), prev, 0, false), errEpochNotMonotonic))
+	reorg := nextEpoch()
+	reorg.ReorgInfo = &types.Reorg{NewSlot: 160}
+	require.NoError(t, validator.validate(reorg, prev, 5, false))
+	assert.Equal(t, true, errors.Is(validator.validate(reorg, prev, 6, false), errReorgBelowFinalized))
+	validator.reset()
+	require.NoError(t, validator.validate(nextEpoch(), prev, 0, false))
+}
+
+func TestService_RejectsInvalidConsensusInfo(t *testing.T) {
+	s, _ := serviceInit(t, 5)
+	consensusInfoCh := make(chan *types.MinimalEpochConsensusInfoV2, 10)
+	sub := s.SubscribeMinConsensusInfoEvent(consensusInfoCh)
+	defer sub.Unsubscribe()
+
+	// the invalid consensus info is neither forwarded nor stopping the processing
+	info := testutil.NewMinimalConsensusInfo(10)
+	info.ValidatorList = info.ValidatorList[:1]
+	require.NoError(t, s.onNewConsensusInfo(context.Background(), info))
+	assert.Equal(t, 0, len(consensusInfoCh))
+	assert.Equal(t, uint64(4), s.db.LatestSavedEpoch())
+	assert.Equal(t, false, s.cursor.hasEpoch)
+
+	require.NoError(t, s.onNewConsensusInfo(context.Background(), testutil.NewMinimalConsensusInfo(10)))
+	assert.Equal(t, 1, len(consensusInfoCh))
+	assert.Equal(t, uint64(10), s.db.LatestSavedEpoch())
+	assert.Equal(t, uint64(10), s.cursor.epoch)
+}
@@ -0,0 +1,191 @@
+// Package backoff retries the connection of a service with exponentially growing, jittered delays and
+// gives up after a configurable number of failed attempts.
+package backoff
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/pkg/errors"
+)
+
+// ErrGaveUp is returned once the maximum number of attempts failed.
+var ErrGaveUp = errors.New("gave up reconnecting")
+
+// permanentError stops the retries of Retry.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps an error which must not be retried, Retry returns it right away.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// Config of the backoff between reconnection attempts.
+type Config struct {
+	InitialInterval time.Duration // delay after the first failed attempt
+	MaxInterval     time.Duration // upper bound of the delay
+	Multiplier      float64       // growth of the delay after every failed attempt
+	Jitter          float64       // delays are randomized by up to this fraction, between 0 and 1
+	MaxAttempts     int           // failed attempts before giving up, 0 retries forever
+}
+
+// DefaultConfig returns the backoff used when none is configured.
+func DefaultConfig() *Config {
+	return &Config{
+		InitialInterval: time.Second,
+		MaxInterval:     time.Minute,
+		Multiplier:      2,
+		Jitter:          0.25,
+	}
+}
+
+// Event is sent to the subscribers after every reconnection attempt.
+type Event struct {
+	Component string        `json:"component"`
+	Attempt   int           `json:"attempt"`
+	Delay     time.Duration `json:"delay"` // delay before the next attempt
+	Error     string        `json:"error"` // error of the failed attempt
+	Connected bool          `json:"connected"`
+	GaveUp    bool          `json:"gaveUp"`
+}
+
+// Backoff retries the reconnection of a component.
+type Backoff struct {
+	component string
+	cfg       *Config
+	lock      sync.Mutex
+	attempts  int
+	rand      *rand.Rand
+
+	feed  event.Feed
+	scope event.SubscriptionScope
+}
+
+// New creates a backoff for the named component. A nil config uses DefaultConfig.
+func New(component string, cfg *Config) *Backoff {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	return &Backoff{
+		component: component,
+		cfg:       cfg,
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Retry calls fn until it succeeds, waiting longer after every failure. It returns ErrGaveUp once the
+// maximum number of attempts failed, the error wrapped by Permanent, or the context error when the
+// context is done first.
+func (b *Backoff) Retry(ctx context.Context, fn func() error) error {
+	for {
+		err := fn()
+		if err == nil {
+			b.succeeded()
+			return nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		if err := b.wait(ctx, err); err != nil {
+			return err
+		}
+	}
+}
+
+// Wait waits for the next delay without calling anything, e.g. before retrying a broken connection.
+func (b *Backoff) Wait(ctx context.Context) error {
+	return b.wait(ctx, nil)
+}
+
+// Reset starts the delays from the initial interval again.
+func (b *Backoff) Reset() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.attempts = 0
+}
+
+// Attempts returns the number of failed attempts since the last success.
+func (b *Backoff) Attempts() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.attempts
+}
+
+// SubscribeEvent registers a subscription of the reconnection attempts.
+func (b *Backoff) SubscribeEvent(ch chan<- *Event) event.Subscription {
+	return b.scope.Track(b.feed.Subscribe(ch))
+}
+
+// Close unsubscribes all the subscribers.
+func (b *Backoff) Close() {
+	b.scope.Close()
+}
+
+// wait records a failed attempt and waits for the delay before the next one.
+func (b *Backoff) wait(ctx context.Context, attemptErr error) error {
+	delay, attempt, gaveUp := b.next()
+	ev := &Event{Component: b.component, Attempt: attempt, Delay: delay, GaveUp: gaveUp}
+	if attemptErr != nil {
+		ev.Error = attemptErr.Error()
+	}
+	reconnectAttempts.WithLabelValues(b.component).Inc()
+	if gaveUp {
+		reconnectGiveUps.WithLabelValues(b.component).Inc()
+		b.feed.Send(ev)
+		return errors.Wrapf(ErrGaveUp, "%s after %d attempts", b.component, attempt)
+	}
+	reconnectDelay.WithLabelValues(b.component).Set(delay.Seconds())
+	b.feed.Send(ev)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// next counts a failed attempt and returns the delay before the next one.
+func (b *Backoff) next() (time.Duration, int, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.attempts++
+	if b.cfg.MaxAttempts > 0 && b.attempts >= b.cfg.MaxAttempts {
+		return 0, b.attempts, true
+	}
+	delay := float64(b.cfg.InitialInterval) * math.Pow(b.cfg.Multiplier, float64(b.attempts-1))
+	if b.cfg.Jitter > 0 {
+		delay += delay * b.cfg.Jitter * (2*b.rand.Float64() - 1)
+	}
+	if max := float64(b.cfg.MaxInterval); max > 0 && delay > max {
+		delay = max
+	}
+	return time.Duration(delay), b.attempts, false
+}
+
+// succeeded resets the attempts after a successful one.
+func (b *Backoff) succeeded() {
+	b.lock.Lock()
+	attempt := b.attempts
+	b.attempts = 0
+	b.lock.Unlock()
+
+	reconnectDelay.WithLabelValues(b.component).Set(0)
+	if attempt > 0 {
+		b.feed.Send(&Event{Component: b.component, Attempt: attempt, Connected: true})
+	}
+}
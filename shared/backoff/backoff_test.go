@@ -0,0 +1,127 @@
+package backoff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/pkg/errors"
+)
+
+func TestBackoff_Delays(t *testing.T) {
+	b := New("test", &Config{
+		InitialInterval: time.Second,
+		MaxInterval:     5 * time.Second,
+		Multiplier:      2,
+	})
+	for _, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		delay, _, gaveUp := b.next()
+		assert.Equal(t, false, gaveUp)
+		assert.Equal(t, want, delay)
+	}
+	assert.Equal(t, 5, b.Attempts())
+
+	b.Reset()
+	delay, attempt, _ := b.next()
+	assert.Equal(t, time.Second, delay)
+	assert.Equal(t, 1, attempt)
+}
+
+func TestBackoff_Jitter(t *testing.T) {
+	b := New("test", &Config{
+		InitialInterval: time.Second,
+		Multiplier:      2,
+		Jitter:          0.5,
+	})
+	for i := 0; i < 100; i++ {
+		b.Reset()
+		delay, _, _ := b.next()
+		if delay < 500*time.Millisecond || delay > 1500*time.Millisecond {
+			t.Fatalf("delay %v out of the jitter range", delay)
+		}
+	}
+}
+
+func TestBackoff_JitterCapped(t *testing.T) {
+	b := New("test", &Config{
+		InitialInterval: time.Second,
+		MaxInterval:     4 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.5,
+	})
+	for i := 0; i < 100; i++ {
+		delay, _, _ := b.next()
+		if delay > 4*time.Second {
+			t.Fatalf("delay %v above the max interval", delay)
+		}
+	}
+}
+
+func TestBackoff_Retry(t *testing.T) {
+	b := New("test", &Config{InitialInterval: time.Millisecond, Multiplier: 2})
+	events := make(chan *Event, 4)
+	sub := b.SubscribeEvent(events)
+	defer sub.Unsubscribe()
+
+	calls := 0
+	require.NoError(t, b.Retry(context.Background(), func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("unreachable")
+		}
+		return nil
+	}))
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 0, b.Attempts())
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		ev := <-events
+		assert.Equal(t, attempt, ev.Attempt)
+		assert.Equal(t, "unreachable", ev.Error)
+	}
+	ev := <-events
+	assert.Equal(t, true, ev.Connected)
+}
+
+func TestBackoff_GiveUp(t *testing.T) {
+	b := New("test", &Config{InitialInterval: time.Millisecond, Multiplier: 2, MaxAttempts: 3})
+	events := make(chan *Event, 4)
+	sub := b.SubscribeEvent(events)
+	defer sub.Unsubscribe()
+
+	calls := 0
+	err := b.Retry(context.Background(), func() error {
+		calls++
+		return errors.New("unreachable")
+	})
+	assert.Equal(t, true, errors.Is(err, ErrGaveUp))
+	assert.Equal(t, 3, calls)
+	<-events
+	<-events
+	ev := <-events
+	assert.Equal(t, true, ev.GaveUp)
+}
+
+func TestBackoff_ContextCancelled(t *testing.T) {
+	b := New("test", &Config{InitialInterval: time.Hour, Multiplier: 2})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := b.Retry(ctx, func() error {
+		return errors.New("unreachable")
+	})
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestBackoff_Permanent(t *testing.T) {
+	b := New("test", &Config{InitialInterval: time.Hour, Multiplier: 2})
+	errMismatch := errors.New("mismatch")
+	calls := 0
+	err := b.Retry(context.Background(), func() error {
+		calls++
+		return errors.Wrap(Permanent(errMismatch), "handshake")
+	})
+	assert.Equal(t, errMismatch, err)
+	assert.Equal(t, 1, calls)
+}
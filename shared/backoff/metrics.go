@@ -0,0 +1,21 @@
+package backoff
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	reconnectAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "orchestrator_reconnect_failed_attempts_total",
+		Help: "Number of failed reconnection attempts per component",
+	}, []string{"component"})
+	reconnectDelay = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "orchestrator_reconnect_backoff_seconds",
+		Help: "Delay before the next reconnection attempt per component, 0 when connected",
+	}, []string{"component"})
+	reconnectGiveUps = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "orchestrator_reconnect_give_ups_total",
+		Help: "Number of times a component gave up reconnecting",
+	}, []string{"component"})
+)
@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 )
 
 const (
@@ -12,11 +14,25 @@ const (
 	DefaultHTTPPort             = 8545        // Default TCP port for the HTTP RPC server
 	DefaultWSHost               = "localhost" // Default host interface for the websocket RPC server
 	DefaultWSPort               = 8546        // Default TCP port for the websocket RPC server
+	DefaultMonitoringHost       = "127.0.0.1" // Default host interface for the prometheus metrics server
+	DefaultMonitoringPort       = 8090        // Default TCP port for the prometheus metrics server
 	DefaultIpcPath              = "orchestrator.ipc"
+	DefaultJWTSecretFileName    = "jwtsecret" // Default file name of the generated JWT secret within the datadir
 	DefaultVanguardGRPCEndpoint = "127.0.0.1:4000"
 	DefaultPandoraRPCEndpoint   = "http://127.0.0.1:8545"
+	DefaultPandoraQuorum        = 0
+
+	DefaultVanguardGRPCMaxMsgSize = math.MaxInt32          // Default maximum size in bytes of a message received from vanguard
+	DefaultVanguardGRPCRetries    = 5                      // Default number of retries of a failed vanguard gRPC call
+	DefaultVanguardGRPCRetryDelay = 100 * time.Millisecond // Default base of the exponential backoff between vanguard gRPC call retries
+
+	DefaultReconnectMaxInterval = time.Minute // Default maximum delay between two reconnection attempts
+	DefaultReconnectMaxAttempts = 0           // Default failed reconnection attempts before giving up, 0 never gives up
 )
 
+// DefaultHTTPVirtualHosts is the default list of virtual hostnames accepted by the HTTP RPC server
+var DefaultHTTPVirtualHosts = []string{"localhost"}
+
 // DefaultConfigDir is the default config directory to use for the vaults and other
 // persistence requirements.
 func DefaultConfigDir() string {
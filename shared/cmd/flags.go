@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/urfave/cli/v2"
 )
 
@@ -23,6 +26,12 @@ var (
 		Usage: "Prompt for clearing any previously stored data at the data directory",
 	}
 
+	// EphemeralFlag keeps the orchestrator database in memory only. Useful for throwaway devnets.
+	EphemeralFlag = &cli.BoolFlag{
+		Name:  "ephemeral",
+		Usage: "Keep the orchestrator database in memory only, nothing is persisted to the data directory",
+	}
+
 	IPCPathFlag = &cli.StringFlag{
 		Name:  "ipcpath",
 		Usage: "Filename for IPC socket/pipe within the datadir (explicit paths escape it)",
@@ -45,6 +54,48 @@ var (
 		Value: DefaultHTTPPort,
 	}
 
+	HTTPCORSDomainFlag = &cli.StringFlag{
+		Name:  "http.corsdomain",
+		Usage: "Comma separated list of domains from which to accept cross origin requests (browser enforced)",
+		Value: "",
+	}
+
+	HTTPVirtualHostsFlag = &cli.StringFlag{
+		Name:  "http.vhosts",
+		Usage: "Comma separated list of virtual hostnames from which to accept requests (server enforced). Accepts '*' wildcard.",
+		Value: strings.Join(DefaultHTTPVirtualHosts, ","),
+	}
+
+	HTTPApiFlag = &cli.StringFlag{
+		Name:  "http.api",
+		Usage: "API's offered over the HTTP-RPC interface, only public API's are offered when empty",
+		Value: "",
+	}
+
+	HTTPPathPrefixFlag = &cli.StringFlag{
+		Name:  "http.rpcprefix",
+		Usage: "HTTP path prefix on which JSON-RPC is served. Use '/' to serve on all paths.",
+		Value: "",
+	}
+
+	HTTPReadTimeoutFlag = &cli.DurationFlag{
+		Name:  "http.readtimeout",
+		Usage: "Maximum duration for reading an entire HTTP-RPC request, including the body",
+		Value: rpc.DefaultHTTPTimeouts.ReadTimeout,
+	}
+
+	HTTPWriteTimeoutFlag = &cli.DurationFlag{
+		Name:  "http.writetimeout",
+		Usage: "Maximum duration before timing out writes of an HTTP-RPC response",
+		Value: rpc.DefaultHTTPTimeouts.WriteTimeout,
+	}
+
+	HTTPIdleTimeoutFlag = &cli.DurationFlag{
+		Name:  "http.idletimeout",
+		Usage: "Maximum amount of time to wait for the next HTTP-RPC request when keep-alives are enabled",
+		Value: rpc.DefaultHTTPTimeouts.IdleTimeout,
+	}
+
 	WSEnabledFlag = &cli.BoolFlag{
 		Name:  "ws",
 		Usage: "Enable the WS-RPC server",
@@ -62,19 +113,159 @@ var (
 		Value: DefaultWSPort,
 	}
 
+	// MonitoringHostFlag defines the host used to serve prometheus metrics.
+	MonitoringHostFlag = &cli.StringFlag{
+		Name:  "monitoring-host",
+		Usage: "Host used for listening and responding metrics for prometheus.",
+		Value: DefaultMonitoringHost,
+	}
+
+	// MonitoringPortFlag defines the http port used to serve prometheus metrics.
+	MonitoringPortFlag = &cli.IntFlag{
+		Name:  "monitoring-port",
+		Usage: "Port used to listening and respond metrics for prometheus.",
+		Value: DefaultMonitoringPort,
+	}
+
+	// DisableMonitoringFlag defines a flag to disable the metrics collection.
+	DisableMonitoringFlag = &cli.BoolFlag{
+		Name:  "disable-monitoring",
+		Usage: "Disable monitoring service.",
+	}
+
+	WSAllowedOriginsFlag = &cli.StringFlag{
+		Name:  "ws.origins",
+		Usage: "Origins from which to accept websockets requests",
+		Value: "",
+	}
+
+	WSApiFlag = &cli.StringFlag{
+		Name:  "ws.api",
+		Usage: "API's offered over the WS-RPC interface, only public API's are offered when empty",
+		Value: "",
+	}
+
+	WSPathPrefixFlag = &cli.StringFlag{
+		Name:  "ws.rpcprefix",
+		Usage: "HTTP path prefix on which JSON-RPC is served over WS. Use '/' to serve on all paths.",
+		Value: "",
+	}
+
+	// AuthRPCJWTSecretFlag points to the hex encoded secret used to authenticate http and websocket rpc requests.
+	AuthRPCJWTSecretFlag = &cli.StringFlag{
+		Name: "authrpc.jwtsecret",
+		Usage: "Path to a hex encoded JWT secret used to authenticate HTTP and WS RPC requests. When set, the orc " +
+			"namespace requires authentication too. Otherwise a secret is generated within the datadir and only guards the admin namespace",
+		Value: "",
+	}
+
+	// RPCTLSCertFlag specifies the certificate served by the HTTP and WS RPC servers.
+	RPCTLSCertFlag = &cli.StringFlag{
+		Name:  "rpc.tls-cert",
+		Usage: "Path to the PEM encoded TLS certificate of the HTTP and WS RPC servers. Reloaded on SIGHUP",
+		Value: "",
+	}
+
+	// RPCTLSKeyFlag specifies the private key of the RPC TLS certificate.
+	RPCTLSKeyFlag = &cli.StringFlag{
+		Name:  "rpc.tls-key",
+		Usage: "Path to the PEM encoded private key of the RPC TLS certificate. Reloaded on SIGHUP",
+		Value: "",
+	}
+
+	// RPCTLSClientCAFlag enables mutual TLS for the HTTP and WS RPC servers.
+	RPCTLSClientCAFlag = &cli.StringFlag{
+		Name:  "rpc.tls-clientca",
+		Usage: "Path to the PEM encoded CA certificates RPC clients must present a certificate of (mutual TLS)",
+		Value: "",
+	}
+
 	VanguardGRPCEndpoint = &cli.StringFlag{
 		Name:  "vanguard-grpc-endpoint",
-		Usage: "Vanguard node gRPC provider endpoint",
+		Usage: "Comma separated list of vanguard node gRPC provider endpoints. The healthiest one is used and the others are failed over to",
 		Value: DefaultVanguardGRPCEndpoint,
 	}
 
-	// PandoraRPCEndpoint provides an WSS/IPC access endpoint to an Pandora RPC.
+	// VanguardTLSCACertFlag enables a TLS connection with the vanguard node.
+	VanguardTLSCACertFlag = &cli.StringFlag{
+		Name:  "vanguard-tls-ca-cert",
+		Usage: "Path to the CA certificate the vanguard node gRPC certificate is verified with. Enables TLS",
+		Value: "",
+	}
+
+	// VanguardTLSClientCertFlag enables mutual TLS with the vanguard node.
+	VanguardTLSClientCertFlag = &cli.StringFlag{
+		Name:  "vanguard-tls-client-cert",
+		Usage: "Path to the client certificate presented to the vanguard node (mutual TLS)",
+		Value: "",
+	}
+
+	// VanguardTLSClientKeyFlag is the private key of the vanguard client certificate.
+	VanguardTLSClientKeyFlag = &cli.StringFlag{
+		Name:  "vanguard-tls-client-key",
+		Usage: "Path to the private key of the vanguard client certificate",
+		Value: "",
+	}
+
+	// VanguardBearerTokenFlag authenticates the gRPC calls to the vanguard node.
+	VanguardBearerTokenFlag = &cli.StringFlag{
+		Name:  "vanguard-bearer-token",
+		Usage: "Bearer token sent within the authorization header of every vanguard gRPC call",
+		Value: "",
+	}
+
+	VanguardGRPCMaxMsgSizeFlag = &cli.IntFlag{
+		Name:  "vanguard-grpc-max-msg-size",
+		Usage: "Maximum size in bytes of a message received from the vanguard node",
+		Value: DefaultVanguardGRPCMaxMsgSize,
+	}
+
+	VanguardGRPCRetriesFlag = &cli.UintFlag{
+		Name:  "vanguard-grpc-retries",
+		Usage: "Number of retries of a failed vanguard gRPC call, streams are not retried",
+		Value: DefaultVanguardGRPCRetries,
+	}
+
+	VanguardGRPCRetryDelayFlag = &cli.DurationFlag{
+		Name:  "vanguard-grpc-retry-delay",
+		Usage: "Base of the exponential backoff between the retries of a failed vanguard gRPC call",
+		Value: DefaultVanguardGRPCRetryDelay,
+	}
+
+	// ReconnectMaxIntervalFlag caps the backoff between two reconnection attempts with a chain node.
+	ReconnectMaxIntervalFlag = &cli.DurationFlag{
+		Name:  "reconnect-max-interval",
+		Usage: "Maximum delay between two attempts to reconnect with a vanguard or pandora node, the delay doubles after every failed attempt",
+		Value: DefaultReconnectMaxInterval,
+	}
+
+	// ReconnectMaxAttemptsFlag marks a chain service unhealthy after the given number of failed reconnection attempts.
+	ReconnectMaxAttemptsFlag = &cli.IntFlag{
+		Name:  "reconnect-max-attempts",
+		Usage: "Failed attempts to reconnect with a vanguard or pandora node before giving up and reporting the service unhealthy, 0 retries forever",
+		Value: DefaultReconnectMaxAttempts,
+	}
+
+	// PandoraRPCEndpoint provides an WSS/IPC access endpoint to an Pandora RPC. HTTP endpoints are polled.
 	PandoraRPCEndpoint = &cli.StringFlag{
 		Name:  "pandora-rpc-endpoint",
-		Usage: "Pandora node RPC provider endpoint",
+		Usage: "Comma separated list of pandora node RPC provider endpoints, the next one is used when the active one fails. HTTP endpoints are polled for new headers",
 		Value: DefaultPandoraRPCEndpoint,
 	}
 
+	// PandoraQuorumFlag enables the quorum mode of the pandora subscription.
+	PandoraQuorumFlag = &cli.IntFlag{
+		Name:  "pandora-quorum",
+		Usage: "Number of pandora endpoints which must report the same header for a slot before it is processed. Disabled when lower than 2",
+		Value: DefaultPandoraQuorum,
+	}
+
+	// RecordStreamsFlag records the vanguard and pandora streams for replaying them offline.
+	RecordStreamsFlag = &cli.StringFlag{
+		Name:  "record-streams",
+		Usage: "File the raw vanguard blocks, consensus infos and pandora headers are recorded to, for replaying them offline. An existing file is overwritten",
+	}
+
 	// VerbosityFlag defines the logrus configuration.
 	VerbosityFlag = &cli.StringFlag{
 		Name:  "verbosity",
@@ -1 +1,12 @@
 package cmd
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func TestSplitAndTrim(t *testing.T) {
+	assert.DeepEqual(t, []string{"orc", "admin"}, SplitAndTrim(" orc, admin ,"))
+	assert.Equal(t, 0, len(SplitAndTrim("")))
+}
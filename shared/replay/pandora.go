@@ -0,0 +1,132 @@
+package replay
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+var errMissingPandoraNetwork = errors.New("recording has no pandora network")
+
+// pandoraAPI replays the pandora pending headers of a recording in the eth namespace.
+type pandoraAPI struct {
+	server *Server
+}
+
+// ChainId returns the recorded chain id.
+func (api *pandoraAPI) ChainId() (*hexutil.Big, error) {
+	if api.server.pandoraGenesis == nil {
+		return nil, errMissingPandoraNetwork
+	}
+	return (*hexutil.Big)(new(big.Int).SetUint64(api.server.chainID)), nil
+}
+
+// GetBlockByNumber returns the recorded genesis header or the latest replayed header of the given number.
+func (api *pandoraAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (*eth1Types.Header, error) {
+	if number == 0 {
+		return api.server.pandoraGenesis, nil
+	}
+	var found *eth1Types.Header
+	api.server.eachDueHeader(func(header *eth1Types.Header) {
+		if header.Number.Int64() == number.Int64() {
+			found = header
+		}
+	})
+	return found, nil
+}
+
+// GetBlockByHash returns the replayed header of the given hash.
+func (api *pandoraAPI) GetBlockByHash(ctx context.Context, hash common.Hash, fullTx bool) (*eth1Types.Header, error) {
+	if genesis := api.server.pandoraGenesis; genesis != nil && genesis.Hash() == hash {
+		return genesis, nil
+	}
+	var found *eth1Types.Header
+	api.server.eachDueHeader(func(header *eth1Types.Header) {
+		if header.Hash() == hash {
+			found = header
+		}
+	})
+	return found, nil
+}
+
+// GetPendingBlockHeaders returns the replayed headers following the header of the filter.
+func (api *pandoraAPI) GetPendingBlockHeaders(
+	ctx context.Context, filter types.PandoraPendingHeaderFilter,
+) ([]*eth1Types.Header, error) {
+	headers := make([]*eth1Types.Header, 0)
+	for _, header := range api.server.headers[api.server.headersFrom(filter.FromBlockHash):] {
+		if !api.server.isDue(header.due) {
+			break
+		}
+		if header.copies() > 0 {
+			headers = append(headers, header.header)
+		}
+	}
+	return headers, nil
+}
+
+// NewPendingBlockHeaders streams the recorded headers following the header of the filter.
+func (api *pandoraAPI) NewPendingBlockHeaders(
+	ctx context.Context, filter types.PandoraPendingHeaderFilter,
+) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	subscription := notifier.CreateSubscription()
+
+	// the subscription outlives the request, it ends when the subscriber leaves
+	subCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-subscription.Err():
+		case <-notifier.Closed():
+		case <-subCtx.Done():
+		}
+		cancel()
+	}()
+
+	go func() {
+		defer cancel()
+		for _, header := range api.server.headers[api.server.headersFrom(filter.FromBlockHash):] {
+			if err := api.server.wait(subCtx, header.due); err != nil {
+				return
+			}
+			for i := 0; i < header.copies(); i++ {
+				if err := notifier.Notify(subscription.ID, header.header); err != nil {
+					log.WithError(err).Debug("Could not send replayed pandora header")
+					return
+				}
+			}
+		}
+		api.server.idle(subCtx)
+	}()
+	return subscription, nil
+}
+
+// headersFrom returns the index of the first recorded header following the one of the given hash, 0 when
+// the hash was not recorded.
+func (s *Server) headersFrom(hash common.Hash) int {
+	for i, header := range s.headers {
+		if header.header.Hash() == hash {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// eachDueHeader calls fn with the replayed headers in the order they were recorded.
+func (s *Server) eachDueHeader(fn func(header *eth1Types.Header)) {
+	for _, header := range s.headers {
+		if !s.isDue(header.due) {
+			return
+		}
+		fn(header.header)
+	}
+}
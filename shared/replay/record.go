@@ -0,0 +1,68 @@
+// Package replay records the raw vanguard and pandora streams received by the orchestrator into a file and
+// serves a recording back over gRPC and JSON-RPC, so a run against live nodes can be reproduced offline.
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"time"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// Kind of a recorded message.
+type Kind string
+
+const (
+	VanguardGenesis       Kind = "vanguardGenesis"       // ethpb.Genesis of the vanguard node
+	VanguardBlock         Kind = "vanguardBlock"         // ethpb.StreamPendingBlockInfo of the pending blocks stream
+	VanguardConsensusInfo Kind = "vanguardConsensusInfo" // ethpb.MinimalConsensusInfo of the consensus info stream
+	PandoraNetwork        Kind = "pandoraNetwork"        // chain id and genesis header of the pandora node
+	PandoraHeader         Kind = "pandoraHeader"         // header of the pending headers stream
+)
+
+var errUnknownKind = errors.New("unknown record kind")
+
+// Record is a message received by the orchestrator along with its arrival time. Vanguard messages are
+// encoded with protojson, pandora messages with their json-rpc encoding.
+type Record struct {
+	Kind Kind            `json:"kind"`
+	Time time.Time       `json:"time"`
+	Data json.RawMessage `json:"data"`
+}
+
+// pandoraNetwork is the data of a PandoraNetwork record.
+type pandoraNetwork struct {
+	ChainID uint64            `json:"chainId"`
+	Genesis *eth1Types.Header `json:"genesis"`
+}
+
+// ReadRecords reads all the records of a recording, in the order they were received.
+func ReadRecords(path string) ([]*Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open recording")
+	}
+	defer file.Close()
+
+	var records []*Record
+	scanner := bufio.NewScanner(file)
+	// vanguard blocks and consensus infos of large validator sets exceed the default line size
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := new(Record)
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, errors.Wrapf(err, "could not decode record at line %d", line)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "could not read recording")
+	}
+	return records, nil
+}
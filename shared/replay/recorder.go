@@ -0,0 +1,178 @@
+package replay
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Recorder writes the messages received from the vanguard and pandora nodes to a file, one json record per
+// line. A nil recorder records nothing, so the chain services call it without checking whether recording
+// is enabled.
+type Recorder struct {
+	lock            sync.Mutex
+	path            string
+	file            *os.File
+	encoder         *json.Encoder
+	err             error // first failed write, recording stops after it
+	records         int
+	genesisRecorded bool
+	networkRecorded bool
+	headers         *lru.Cache // recent headers, headers reported by several pandora nodes are recorded once
+}
+
+// number of recent pandora header hashes kept to record the headers of several pandora nodes once.
+const recordedHeadersSize = 1 << 10
+
+// NewRecorder creates a recorder writing to the given file. An existing file is overwritten.
+func NewRecorder(path string) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create recording")
+	}
+	headers, err := lru.New(recordedHeadersSize)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &Recorder{
+		path:    path,
+		file:    file,
+		encoder: json.NewEncoder(file),
+		headers: headers,
+	}, nil
+}
+
+// Start implements shared.Service, records are written as soon as they arrive.
+func (r *Recorder) Start() {
+	log.WithField("path", r.path).Info("Recording vanguard and pandora streams")
+}
+
+// Stop closes the recording.
+func (r *Recorder) Stop() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	log.WithField("path", r.path).WithField("records", r.records).Info("Stopped recording")
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// Status returns the error which stopped the recording, if any.
+func (r *Recorder) Status() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.err
+}
+
+// Records returns the number of written records.
+func (r *Recorder) Records() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.records
+}
+
+// RecordVanguardGenesis records the genesis of the first connected vanguard node.
+func (r *Recorder) RecordVanguardGenesis(genesis *ethpb.Genesis) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.genesisRecorded {
+		return
+	}
+	r.genesisRecorded = true
+	r.writeProto(VanguardGenesis, genesis)
+}
+
+// RecordVanguardBlock records a block of the pending blocks stream.
+func (r *Recorder) RecordVanguardBlock(blockInfo *ethpb.StreamPendingBlockInfo) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.writeProto(VanguardBlock, blockInfo)
+}
+
+// RecordVanguardConsensusInfo records a consensus info of the minimal consensus info stream.
+func (r *Recorder) RecordVanguardConsensusInfo(consensusInfo *ethpb.MinimalConsensusInfo) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.writeProto(VanguardConsensusInfo, consensusInfo)
+}
+
+// RecordPandoraNetwork records the chain id and the genesis header of the first connected pandora node.
+func (r *Recorder) RecordPandoraNetwork(chainID uint64, genesis *eth1Types.Header) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.networkRecorded {
+		return
+	}
+	r.networkRecorded = true
+	r.writeJSON(PandoraNetwork, &pandoraNetwork{ChainID: chainID, Genesis: genesis})
+}
+
+// RecordPandoraHeader records a header of the pending headers stream.
+func (r *Recorder) RecordPandoraHeader(header *eth1Types.Header) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if ok, _ := r.headers.ContainsOrAdd(header.Hash(), struct{}{}); ok {
+		return
+	}
+	r.writeJSON(PandoraHeader, header)
+}
+
+// writeProto writes a record of a vanguard message.
+func (r *Recorder) writeProto(kind Kind, msg proto.Message) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		log.WithError(err).WithField("kind", kind).Warn("Could not encode recorded message")
+		return
+	}
+	r.write(kind, data)
+}
+
+// writeJSON writes a record of a pandora message.
+func (r *Recorder) writeJSON(kind Kind, msg interface{}) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.WithError(err).WithField("kind", kind).Warn("Could not encode recorded message")
+		return
+	}
+	r.write(kind, data)
+}
+
+// write appends a record to the file. The caller holds the lock.
+func (r *Recorder) write(kind Kind, data []byte) {
+	if r.file == nil || r.err != nil {
+		return
+	}
+	if err := r.encoder.Encode(&Record{Kind: kind, Time: time.Now(), Data: data}); err != nil {
+		log.WithError(err).WithField("path", r.path).Error("Could not write recording, recording stopped")
+		r.err = errors.Wrap(err, "could not write recording")
+		return
+	}
+	r.records++
+}
@@ -0,0 +1,107 @@
+package replay
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// newConsensusInfo returns a vanguard consensus info of the given epoch.
+func newConsensusInfo(epoch uint64) *ethpb.MinimalConsensusInfo {
+	info := testutil.NewMinimalConsensusInfo(epoch)
+	return &ethpb.MinimalConsensusInfo{
+		Epoch:            eth2Types.Epoch(epoch),
+		ValidatorList:    info.ValidatorList,
+		EpochTimeStart:   info.EpochStartTime,
+		SlotTimeDuration: &durationpb.Duration{Seconds: int64(info.SlotTimeDuration)},
+	}
+}
+
+// record writes a recording of the given number of slots and returns its path.
+func record(t *testing.T, slots uint64) string {
+	path := filepath.Join(t.TempDir(), "streams.jsonl")
+	recorder, err := NewRecorder(path)
+	require.NoError(t, err)
+
+	recorder.RecordVanguardGenesis(&ethpb.Genesis{GenesisTime: &timestamp.Timestamp{Seconds: 1624266000}})
+	recorder.RecordPandoraNetwork(1, testutil.NewEth1Header(0))
+	recorder.RecordVanguardConsensusInfo(newConsensusInfo(0))
+	for slot := uint64(1); slot <= slots; slot++ {
+		header := testutil.NewEth1Header(slot)
+		block := testutil.NewBeaconBlock(slot)
+		block.Body.PandoraShard[0].SealHash = testutil.SealHash(header).Bytes()
+		recorder.RecordVanguardBlock(&ethpb.StreamPendingBlockInfo{Block: block, FinalizedSlot: eth2Types.Slot(slot / 2)})
+		recorder.RecordPandoraHeader(header)
+	}
+	require.NoError(t, recorder.Stop())
+	require.NoError(t, recorder.Status())
+	return path
+}
+
+func TestRecorder_Record(t *testing.T) {
+	path := record(t, 3)
+	records, err := ReadRecords(path)
+	require.NoError(t, err)
+
+	kinds := make([]Kind, 0, len(records))
+	for _, record := range records {
+		kinds = append(kinds, record.Kind)
+	}
+	assert.DeepEqual(t, []Kind{
+		VanguardGenesis, PandoraNetwork, VanguardConsensusInfo,
+		VanguardBlock, PandoraHeader, VanguardBlock, PandoraHeader, VanguardBlock, PandoraHeader,
+	}, kinds)
+
+	// the recorded messages are decoded back unchanged
+	s, err := NewServer(&Config{Records: records})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1624266000), s.genesis.GenesisTime.Seconds)
+	assert.Equal(t, uint64(1), s.chainID)
+	assert.Equal(t, testutil.NewEth1Header(0).Hash(), s.pandoraGenesis.Hash())
+	require.Equal(t, 3, len(s.blocks))
+	assert.Equal(t, eth2Types.Slot(2), s.blocks[1].info.Block.Slot)
+	assert.Equal(t, eth2Types.Slot(1), s.blocks[1].info.FinalizedSlot)
+	assert.DeepEqual(t, testutil.NewPandoraShard(testutil.NewEth1Header(2)).Hash, s.blocks[1].info.Block.Body.PandoraShard[0].Hash)
+	require.Equal(t, 1, len(s.consensusInfos))
+	assert.DeepEqual(t, newConsensusInfo(0).ValidatorList, s.consensusInfos[0].info.ValidatorList)
+	require.Equal(t, 3, len(s.headers))
+	assert.Equal(t, testutil.NewEth1Header(3).Hash(), s.headers[2].header.Hash())
+}
+
+func TestRecorder_RecordsOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "streams.jsonl")
+	recorder, err := NewRecorder(path)
+	require.NoError(t, err)
+
+	// headers reported by several pandora nodes and the networks of reconnections are recorded once
+	header := testutil.NewEth1Header(1)
+	recorder.RecordPandoraHeader(header)
+	recorder.RecordPandoraHeader(header)
+	recorder.RecordPandoraNetwork(1, testutil.NewEth1Header(0))
+	recorder.RecordPandoraNetwork(2, testutil.NewEth1Header(0))
+	recorder.RecordVanguardGenesis(&ethpb.Genesis{})
+	recorder.RecordVanguardGenesis(&ethpb.Genesis{})
+	assert.Equal(t, 3, recorder.Records())
+
+	// only the recent headers are remembered
+	for number := uint64(2); number < recordedHeadersSize+2; number++ {
+		recorder.RecordPandoraHeader(testutil.NewEth1Header(number))
+	}
+	assert.Equal(t, recordedHeadersSize, recorder.headers.Len())
+	recorder.RecordPandoraHeader(header)
+	assert.Equal(t, recordedHeadersSize+4, recorder.Records())
+
+	// nothing is recorded after stopping, nor by a nil recorder
+	require.NoError(t, recorder.Stop())
+	recorder.RecordPandoraHeader(testutil.NewEth1Header(2))
+	assert.Equal(t, recordedHeadersSize+4, recorder.Records())
+	var disabled *Recorder
+	disabled.RecordVanguardBlock(&ethpb.StreamPendingBlockInfo{})
+}
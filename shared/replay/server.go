@@ -0,0 +1,285 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var errServerStopped = errors.New("replay server stopped")
+
+// Faults injected into the replayed streams. Random faults are drawn once per record, so every stream
+// serving a record drops or duplicates it alike and replays with the same seed inject the same faults.
+type Faults struct {
+	DropRate        float64 // fraction of the streamed records which are never sent
+	DuplicateRate   float64 // fraction of the streamed records which are sent twice
+	DisconnectAfter int     // the first vanguard streams break with codes.Unavailable after this many sent records, 0 never
+	Seed            int64   // seed of the random faults
+}
+
+// Config of a replay server.
+type Config struct {
+	Records   []*Record
+	TimeScale float64 // speed of the replay, 2 replays twice as fast as recorded, 0 serves all records right away
+	Faults    Faults
+}
+
+// replayed is the schedule of a streamed record.
+type replayed struct {
+	due       time.Duration // offset from the start of the replay
+	drop      bool
+	duplicate bool
+}
+
+// copies returns how many times the record is sent.
+func (r *replayed) copies() int {
+	switch {
+	case r.drop:
+		return 0
+	case r.duplicate:
+		return 2
+	default:
+		return 1
+	}
+}
+
+type replayedBlock struct {
+	replayed
+	info *ethpb.StreamPendingBlockInfo
+}
+
+type replayedConsensusInfo struct {
+	replayed
+	info *ethpb.MinimalConsensusInfo
+}
+
+type replayedHeader struct {
+	replayed
+	header *eth1Types.Header
+}
+
+// Server serves a recording the way the recorded vanguard node serves gRPC and the recorded pandora
+// node serves JSON-RPC. Streams send every record once its recorded offset from the first record,
+// scaled by the time scale, elapsed since the server started, like a live node streaming new data.
+type Server struct {
+	cfg            *Config
+	genesis        *ethpb.Genesis
+	chainID        uint64
+	pandoraGenesis *eth1Types.Header
+	blocks         []*replayedBlock
+	consensusInfos []*replayedConsensusInfo
+	headers        []*replayedHeader
+
+	lock       sync.Mutex
+	start      time.Time
+	streams    map[Kind]int // opened vanguard streams per kind, for the injected disconnects
+	grpcServer *grpc.Server
+	rpcServer  *rpc.Server
+	stop       chan struct{}
+	stopOnce   sync.Once
+}
+
+// NewServer decodes the records of a recording and schedules them.
+func NewServer(cfg *Config) (*Server, error) {
+	s := &Server{
+		cfg:     cfg,
+		streams: make(map[Kind]int),
+		stop:    make(chan struct{}),
+	}
+	rng := rand.New(rand.NewSource(cfg.Faults.Seed))
+	var first time.Time
+	for i, record := range cfg.Records {
+		if i == 0 {
+			first = record.Time
+		}
+		var scheduled replayed
+		if cfg.TimeScale > 0 {
+			scheduled.due = time.Duration(float64(record.Time.Sub(first)) / cfg.TimeScale)
+		}
+		if record.Kind == VanguardBlock || record.Kind == VanguardConsensusInfo || record.Kind == PandoraHeader {
+			scheduled.drop = rng.Float64() < cfg.Faults.DropRate
+			scheduled.duplicate = !scheduled.drop && rng.Float64() < cfg.Faults.DuplicateRate
+		}
+
+		if err := s.schedule(record, scheduled); err != nil {
+			return nil, errors.Wrapf(err, "could not decode record %d of kind %s", i, record.Kind)
+		}
+	}
+
+	s.rpcServer = rpc.NewServer()
+	if err := s.rpcServer.RegisterName("eth", &pandoraAPI{server: s}); err != nil {
+		return nil, err
+	}
+	s.grpcServer = grpc.NewServer()
+	ethpb.RegisterBeaconChainServer(s.grpcServer, &beaconChainServer{server: s})
+	ethpb.RegisterNodeServer(s.grpcServer, &nodeServer{server: s})
+	return s, nil
+}
+
+// schedule decodes a record into the messages served by the replay.
+func (s *Server) schedule(record *Record, scheduled replayed) error {
+	switch record.Kind {
+	case VanguardGenesis:
+		genesis := new(ethpb.Genesis)
+		if err := protojson.Unmarshal(record.Data, genesis); err != nil {
+			return err
+		}
+		if s.genesis == nil {
+			s.genesis = genesis
+		}
+	case VanguardBlock:
+		info := new(ethpb.StreamPendingBlockInfo)
+		if err := protojson.Unmarshal(record.Data, info); err != nil {
+			return err
+		}
+		s.blocks = append(s.blocks, &replayedBlock{replayed: scheduled, info: info})
+	case VanguardConsensusInfo:
+		info := new(ethpb.MinimalConsensusInfo)
+		if err := protojson.Unmarshal(record.Data, info); err != nil {
+			return err
+		}
+		s.consensusInfos = append(s.consensusInfos, &replayedConsensusInfo{replayed: scheduled, info: info})
+	case PandoraNetwork:
+		network := new(pandoraNetwork)
+		if err := json.Unmarshal(record.Data, network); err != nil {
+			return err
+		}
+		if s.pandoraGenesis == nil {
+			s.chainID, s.pandoraGenesis = network.ChainID, network.Genesis
+		}
+	case PandoraHeader:
+		header := new(eth1Types.Header)
+		if err := json.Unmarshal(record.Data, header); err != nil {
+			return err
+		}
+		s.headers = append(s.headers, &replayedHeader{replayed: scheduled, header: header})
+	default:
+		return errUnknownKind
+	}
+	return nil
+}
+
+// Start starts the replay clock. Streams opened before wait for it.
+func (s *Server) Start() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.start.IsZero() {
+		s.start = time.Now()
+		log.WithField("blocks", len(s.blocks)).WithField("consensusInfos", len(s.consensusInfos)).
+			WithField("headers", len(s.headers)).WithField("timeScale", s.cfg.TimeScale).Info("Started replay")
+	}
+}
+
+// ServeGRPC serves the vanguard node of the recording on the given address, e.g. 127.0.0.1:0, and returns
+// the address it listens on.
+func (s *Server) ServeGRPC(address string) (string, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", errors.Wrap(err, "could not listen for the replayed vanguard node")
+	}
+	go func() {
+		if err := s.grpcServer.Serve(listener); err != nil {
+			log.WithError(err).Debug("Stopped serving the replayed vanguard node")
+		}
+	}()
+	return listener.Addr().String(), nil
+}
+
+// RPCServer returns the JSON-RPC server of the pandora node of the recording, e.g. for rpc.DialInProc.
+func (s *Server) RPCServer() *rpc.Server {
+	return s.rpcServer
+}
+
+// Stop stops serving and ends all open streams.
+func (s *Server) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		s.grpcServer.Stop()
+		s.rpcServer.Stop()
+	})
+}
+
+// elapsed returns the replayed time, or false when the replay did not start.
+func (s *Server) elapsed() (time.Duration, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.start.IsZero() {
+		return 0, false
+	}
+	return time.Since(s.start), true
+}
+
+// isDue returns true when a record scheduled at the given offset is served.
+func (s *Server) isDue(due time.Duration) bool {
+	elapsed, started := s.elapsed()
+	return started && elapsed >= due
+}
+
+// wait blocks until a record scheduled at the given offset is due.
+func (s *Server) wait(ctx context.Context, due time.Duration) error {
+	for {
+		elapsed, started := s.elapsed()
+		if started && elapsed >= due {
+			return nil
+		}
+		delay := due - elapsed
+		if !started {
+			// polls the start of the replay
+			delay = 10 * time.Millisecond
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-s.stop:
+			timer.Stop()
+			return errServerStopped
+		}
+	}
+}
+
+// idle blocks an exhausted stream until it is closed, like a live node waiting for new data.
+func (s *Server) idle(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.stop:
+		return errServerStopped
+	}
+}
+
+// disconnectAfter returns the number of records a new stream of the given kind sends before the injected
+// disconnect, 0 when the stream is not broken.
+func (s *Server) disconnectAfter(kind Kind) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.streams[kind]++
+	if s.streams[kind] > 1 {
+		return 0
+	}
+	return s.cfg.Faults.DisconnectAfter
+}
+
+// slotsPerEpoch returns the number of validators of the recorded consensus infos, which is the number of
+// slots of an epoch.
+func (s *Server) slotsPerEpoch() uint64 {
+	for _, consensusInfo := range s.consensusInfos {
+		if n := len(consensusInfo.info.ValidatorList); n > 0 {
+			return uint64(n)
+		}
+	}
+	return types.SlotsPerEpoch
+}
@@ -0,0 +1,181 @@
+package replay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// replayServer starts a replay of a recording of the given number of slots.
+func replayServer(t *testing.T, slots uint64, timeScale float64, faults Faults) *Server {
+	records, err := ReadRecords(record(t, slots))
+	require.NoError(t, err)
+	s, err := NewServer(&Config{Records: records, TimeScale: timeScale, Faults: faults})
+	require.NoError(t, err)
+	t.Cleanup(s.Stop)
+	s.Start()
+	return s
+}
+
+// dialBeaconChain connects to the replayed vanguard node.
+func dialBeaconChain(t *testing.T, s *Server) (ethpb.BeaconChainClient, ethpb.NodeClient) {
+	address, err := s.ServeGRPC("127.0.0.1:0")
+	require.NoError(t, err)
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return ethpb.NewBeaconChainClient(conn), ethpb.NewNodeClient(conn)
+}
+
+// receiveSlots receives blocks until the stream breaks or the given number of blocks arrived.
+func receiveSlots(stream ethpb.BeaconChain_StreamNewPendingBlocksClient, n int) ([]eth2Types.Slot, error) {
+	var slots []eth2Types.Slot
+	for len(slots) < n {
+		blockInfo, err := stream.Recv()
+		if err != nil {
+			return slots, err
+		}
+		slots = append(slots, blockInfo.Block.Slot)
+	}
+	return slots, nil
+}
+
+func TestServer_VanguardStreams(t *testing.T) {
+	s := replayServer(t, 5, 0, Faults{DisconnectAfter: 2})
+	beaconClient, nodeClient := dialBeaconChain(t, s)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	genesis, err := nodeClient.GetGenesis(ctx, &empty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1624266000), genesis.GenesisTime.Seconds)
+
+	// the first stream breaks after the injected disconnect
+	stream, err := beaconClient.StreamNewPendingBlocks(ctx, &ethpb.StreamPendingBlocksRequest{FromSlot: 2})
+	require.NoError(t, err)
+	slots, err := receiveSlots(stream, 4)
+	assert.DeepEqual(t, []eth2Types.Slot{2, 3}, slots)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// the following streams resume from the requested slot
+	stream, err = beaconClient.StreamNewPendingBlocks(ctx, &ethpb.StreamPendingBlocksRequest{FromSlot: 3})
+	require.NoError(t, err)
+	slots, err = receiveSlots(stream, 3)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []eth2Types.Slot{3, 4, 5}, slots)
+
+	consensusStream, err := beaconClient.StreamMinimalConsensusInfo(ctx, &ethpb.MinimalConsensusInfoRequest{})
+	require.NoError(t, err)
+	consensusInfo, err := consensusStream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, eth2Types.Epoch(0), consensusInfo.Epoch)
+
+	chainHead, err := beaconClient.GetChainHead(ctx, &empty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, eth2Types.Slot(5), chainHead.HeadSlot)
+	assert.Equal(t, eth2Types.Slot(2), chainHead.FinalizedSlot)
+
+	blocks, err := beaconClient.ListBlocks(ctx, &ethpb.ListBlocksRequest{
+		QueryFilter: &ethpb.ListBlocksRequest_Slot{Slot: 4},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(blocks.BlockContainers))
+	assert.Equal(t, true, blocks.BlockContainers[0].Canonical)
+	assert.Equal(t, eth2Types.Slot(4), blocks.BlockContainers[0].Block.Block.Slot)
+}
+
+func TestServer_PandoraHeaders(t *testing.T) {
+	s := replayServer(t, 4, 0, Faults{DuplicateRate: 1})
+	client := rpc.DialInProc(s.RPCServer())
+	defer client.Close()
+	ctx := context.Background()
+
+	var genesis *eth1Types.Header
+	require.NoError(t, client.CallContext(ctx, &genesis, "eth_getBlockByNumber", "0x0", false))
+	assert.Equal(t, testutil.NewEth1Header(0).Hash(), genesis.Hash())
+
+	// headers are streamed following the header of the filter, duplicated as injected
+	ch := make(chan *eth1Types.Header, 10)
+	filter := &types.PandoraPendingHeaderFilter{FromBlockHash: testutil.NewEth1Header(2).Hash()}
+	sub, err := client.Subscribe(ctx, "eth", ch, "newPendingBlockHeaders", filter)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	var numbers []uint64
+	for len(numbers) < 4 {
+		select {
+		case header := <-ch:
+			numbers = append(numbers, header.Number.Uint64())
+		case <-time.After(time.Second):
+			t.Fatalf("Received headers %v", numbers)
+		}
+	}
+	assert.DeepEqual(t, []uint64{3, 3, 4, 4}, numbers)
+
+	var headers []*eth1Types.Header
+	require.NoError(t, client.CallContext(ctx, &headers, "eth_getPendingBlockHeaders", filter))
+	require.Equal(t, 2, len(headers))
+	assert.Equal(t, testutil.NewEth1Header(4).Hash(), headers[1].Hash())
+}
+
+func TestServer_Faults(t *testing.T) {
+	records, err := ReadRecords(record(t, 50))
+	require.NoError(t, err)
+	faults := Faults{DropRate: 0.5, Seed: 7}
+	first, err := NewServer(&Config{Records: records, Faults: faults})
+	require.NoError(t, err)
+	second, err := NewServer(&Config{Records: records, Faults: faults})
+	require.NoError(t, err)
+
+	// replays with the same seed drop the same records
+	dropped := 0
+	for i, header := range first.headers {
+		assert.Equal(t, header.drop, second.headers[i].drop)
+		if header.drop {
+			dropped++
+		}
+	}
+	assert.Equal(t, true, dropped > 10 && dropped < 40, "dropped %d of 50 headers", dropped)
+}
+
+func TestServer_TimeScale(t *testing.T) {
+	records, err := ReadRecords(record(t, 2))
+	require.NoError(t, err)
+	// the second header arrived ten seconds after the first one
+	start := records[0].Time
+	for _, record := range records {
+		record.Time = start
+	}
+	records[len(records)-1].Time = start.Add(10 * time.Second)
+
+	s, err := NewServer(&Config{Records: records, TimeScale: 50})
+	require.NoError(t, err)
+	defer s.Stop()
+	client := rpc.DialInProc(s.RPCServer())
+	defer client.Close()
+
+	pendingHeaders := func() int {
+		var headers []*eth1Types.Header
+		require.NoError(t, client.Call(&headers, "eth_getPendingBlockHeaders",
+			&types.PandoraPendingHeaderFilter{FromBlockHash: common.Hash{}}))
+		return len(headers)
+	}
+	assert.Equal(t, 0, pendingHeaders())
+	s.Start()
+	assert.Equal(t, 1, pendingHeaders())
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, 2, pendingHeaders())
+}
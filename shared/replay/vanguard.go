@@ -0,0 +1,146 @@
+package replay
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// beaconChainServer replays the vanguard streams of a recording.
+type beaconChainServer struct {
+	ethpb.UnimplementedBeaconChainServer
+	server *Server
+}
+
+// StreamNewPendingBlocks streams the recorded blocks from the requested slot on.
+func (b *beaconChainServer) StreamNewPendingBlocks(
+	req *ethpb.StreamPendingBlocksRequest,
+	stream ethpb.BeaconChain_StreamNewPendingBlocksServer,
+) error {
+	ctx := stream.Context()
+	disconnectAfter := b.server.disconnectAfter(VanguardBlock)
+	sent := 0
+	for _, block := range b.server.blocks {
+		if block.info.GetBlock().GetSlot() < req.FromSlot {
+			continue
+		}
+		if err := b.server.wait(ctx, block.due); err != nil {
+			return err
+		}
+		for i := 0; i < block.copies(); i++ {
+			if disconnectAfter > 0 && sent >= disconnectAfter {
+				return status.Error(codes.Unavailable, "replay: injected disconnect")
+			}
+			if err := stream.Send(block.info); err != nil {
+				return err
+			}
+			sent++
+		}
+	}
+	return b.server.idle(ctx)
+}
+
+// StreamMinimalConsensusInfo streams the recorded consensus infos from the requested epoch on.
+func (b *beaconChainServer) StreamMinimalConsensusInfo(
+	req *ethpb.MinimalConsensusInfoRequest,
+	stream ethpb.BeaconChain_StreamMinimalConsensusInfoServer,
+) error {
+	ctx := stream.Context()
+	disconnectAfter := b.server.disconnectAfter(VanguardConsensusInfo)
+	sent := 0
+	for _, consensusInfo := range b.server.consensusInfos {
+		if consensusInfo.info.Epoch < req.FromEpoch {
+			continue
+		}
+		if err := b.server.wait(ctx, consensusInfo.due); err != nil {
+			return err
+		}
+		for i := 0; i < consensusInfo.copies(); i++ {
+			if disconnectAfter > 0 && sent >= disconnectAfter {
+				return status.Error(codes.Unavailable, "replay: injected disconnect")
+			}
+			if err := stream.Send(consensusInfo.info); err != nil {
+				return err
+			}
+			sent++
+		}
+	}
+	return b.server.idle(ctx)
+}
+
+// GetChainHead returns the highest replayed block along with the finality of the latest replayed one.
+func (b *beaconChainServer) GetChainHead(context.Context, *empty.Empty) (*ethpb.ChainHead, error) {
+	chainHead := &ethpb.ChainHead{
+		HeadBlockRoot:      make([]byte, 32),
+		FinalizedBlockRoot: make([]byte, 32),
+		JustifiedBlockRoot: make([]byte, 32),
+	}
+	for _, block := range b.server.blocks {
+		if !b.server.isDue(block.due) {
+			break
+		}
+		chainHead.FinalizedSlot = block.info.FinalizedSlot
+		chainHead.FinalizedEpoch = block.info.FinalizedEpoch
+		if block.info.GetBlock().GetSlot() < chainHead.HeadSlot {
+			continue
+		}
+		root, err := block.info.Block.HashTreeRoot()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		chainHead.HeadSlot = block.info.Block.Slot
+		chainHead.HeadBlockRoot = root[:]
+	}
+	chainHead.HeadEpoch = eth2Types.Epoch(uint64(chainHead.HeadSlot) / b.server.slotsPerEpoch())
+	return chainHead, nil
+}
+
+// ListBlocks returns the replayed blocks of the requested slot, the latest one is canonical. Only the
+// slot filter is supported.
+func (b *beaconChainServer) ListBlocks(ctx context.Context, req *ethpb.ListBlocksRequest) (*ethpb.ListBlocksResponse, error) {
+	filter, ok := req.QueryFilter.(*ethpb.ListBlocksRequest_Slot)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "replay only lists blocks by slot")
+	}
+	res := new(ethpb.ListBlocksResponse)
+	for _, block := range b.server.blocks {
+		if !b.server.isDue(block.due) {
+			break
+		}
+		if block.info.GetBlock().GetSlot() != filter.Slot {
+			continue
+		}
+		root, err := block.info.Block.HashTreeRoot()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		for _, container := range res.BlockContainers {
+			container.Canonical = false
+		}
+		res.BlockContainers = append(res.BlockContainers, &ethpb.BeaconBlockContainer{
+			Block:     &ethpb.SignedBeaconBlock{Block: block.info.Block, Signature: make([]byte, 96)},
+			BlockRoot: root[:],
+			Canonical: true,
+		})
+	}
+	res.TotalSize = int32(len(res.BlockContainers))
+	return res, nil
+}
+
+// nodeServer serves the genesis of the recorded vanguard node.
+type nodeServer struct {
+	ethpb.UnimplementedNodeServer
+	server *Server
+}
+
+// GetGenesis returns the recorded genesis.
+func (n *nodeServer) GetGenesis(context.Context, *empty.Empty) (*ethpb.Genesis, error) {
+	if n.server.genesis == nil {
+		return nil, status.Error(codes.NotFound, "recording has no vanguard genesis")
+	}
+	return n.server.genesis, nil
+}
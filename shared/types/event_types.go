@@ -9,6 +9,13 @@ import (
 
 const BLSSignatureSize = 96
 
+const (
+	// BLSPubKeySize is the length of a compressed BLS public key in bytes.
+	BLSPubKeySize = 48
+	// SlotsPerEpoch is the number of slots, thus of proposers, of a vanguard epoch.
+	SlotsPerEpoch = 32
+)
+
 type Reorg struct {
 	VanParentHash []byte `json:"van_parent_hash"`
 	PanParentHash []byte `json:"pan_parent_hash"`
@@ -22,6 +29,8 @@ type MinimalEpochConsensusInfoV2 struct {
 	SlotTimeDuration time.Duration `json:"slotTimeDuration"`
 	ReorgInfo        *Reorg        `json:"reorg_info"`
 	FinalizedSlot    uint64        `json:"finalizedSlot"`
+	Version          uint64        `json:"version"`  // number of times the epoch has been replaced
+	Replaced         bool          `json:"replaced"` // true when the epoch replaces a different stored one
 }
 
 type MinimalEpochConsensusInfo struct {
@@ -29,6 +38,7 @@ type MinimalEpochConsensusInfo struct {
 	ValidatorList    []string      `json:"validatorList"`
 	EpochStartTime   uint64        `json:"epochTimeStart"`
 	SlotTimeDuration time.Duration `json:"slotTimeDuration"`
+	Version          uint64        `json:"version"` // number of times the epoch has been replaced
 }
 
 type BlockStatus struct {
@@ -54,6 +64,12 @@ type PandoraHeaderInfo struct {
 	Header *eth1Types.Header
 }
 
+// PandoraHeaderDivergence lists the pandora endpoints per header hash they reported for the same slot
+type PandoraHeaderDivergence struct {
+	Slot      uint64                   `json:"slot"`
+	Endpoints map[common.Hash][]string `json:"endpoints"`
+}
+
 type ShutDownSignal struct {
 	Shutdown bool
 }
@@ -82,6 +98,7 @@ func (info *MinimalEpochConsensusInfoV2) ConvertToEpochInfo() *MinimalEpochConse
 		ValidatorList:    info.ValidatorList,
 		EpochStartTime:   info.EpochStartTime,
 		SlotTimeDuration: info.SlotTimeDuration,
+		Version:          info.Version,
 	}
 }
 
@@ -92,6 +109,7 @@ func (info *MinimalEpochConsensusInfo) ConvertToEpochInfoV2() *MinimalEpochConse
 		EpochStartTime:   info.EpochStartTime,
 		SlotTimeDuration: info.SlotTimeDuration,
 		ReorgInfo:        nil,
+		Version:          info.Version,
 	}
 }
 
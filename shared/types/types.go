@@ -10,11 +10,12 @@ import (
 type Status string
 
 const (
-	Pending  Status = "Pending"
-	Verified Status = "Verified"
-	Invalid  Status = "Invalid"
-	Skipped  Status = "Skipped"
-	Unknown  Status = "Unknown"
+	Pending   Status = "Pending"
+	Verified  Status = "Verified"
+	Finalized Status = "Finalized"
+	Invalid   Status = "Invalid"
+	Skipped   Status = "Skipped"
+	Unknown   Status = "Unknown"
 )
 
 // ExtraData
@@ -46,3 +47,60 @@ func CopyHeader(h *eth1Types.Header) *eth1Types.Header {
 	}
 	return &cpy
 }
+
+// HeadState holds the latest info markers of the orchestrator database
+type HeadState struct {
+	LatestEpoch              uint64      `json:"latestEpoch"`
+	LatestVerifiedSlot       uint64      `json:"latestVerifiedSlot"`
+	LatestVerifiedHeaderHash common.Hash `json:"latestVerifiedHeaderHash"`
+	LatestFinalizedSlot      uint64      `json:"latestFinalizedSlot"`
+	LatestFinalizedEpoch     uint64      `json:"latestFinalizedEpoch"`
+}
+
+// Copy returns a copy of the head state
+func (hs *HeadState) Copy() *HeadState {
+	cpy := *hs
+	return &cpy
+}
+
+// SyncStatus describes how far the orchestrator is behind the chains it orchestrates
+type SyncStatus struct {
+	VanguardConnected  bool   `json:"vanguardConnected"`
+	PandoraConnected   bool   `json:"pandoraConnected"`
+	LatestVerifiedSlot uint64 `json:"latestVerifiedSlot"`
+	VanguardHeadSlot   uint64 `json:"vanguardHeadSlot"`
+	SlotLag            uint64 `json:"slotLag"`
+	ReorgInProgress    bool   `json:"reorgInProgress"`
+	Syncing            bool   `json:"syncing"`
+}
+
+// Ready returns true when both chains are connected and the orchestrator is caught up with vanguard
+func (ss *SyncStatus) Ready() bool {
+	return ss.VanguardConnected && ss.PandoraConnected && !ss.Syncing
+}
+
+// PandoraNetwork identifies the pandora network the orchestrator database was built for
+type PandoraNetwork struct {
+	ChainID     uint64      `json:"chainId"`
+	GenesisHash common.Hash `json:"genesisHash"`
+}
+
+// VanguardNetwork identifies the vanguard network the orchestrator database was built for
+type VanguardNetwork struct {
+	GenesisTime           uint64         `json:"genesisTime"`
+	GenesisValidatorsRoot common.Hash    `json:"genesisValidatorsRoot"`
+	DepositContract       common.Address `json:"depositContract"`
+}
+
+// VanguardChainHead holds the head along with the justified and finalized checkpoints of the vanguard chain
+type VanguardChainHead struct {
+	HeadSlot           uint64      `json:"headSlot"`
+	HeadEpoch          uint64      `json:"headEpoch"`
+	HeadBlockRoot      common.Hash `json:"headBlockRoot"`
+	JustifiedSlot      uint64      `json:"justifiedSlot"`
+	JustifiedEpoch     uint64      `json:"justifiedEpoch"`
+	JustifiedBlockRoot common.Hash `json:"justifiedBlockRoot"`
+	FinalizedSlot      uint64      `json:"finalizedSlot"`
+	FinalizedEpoch     uint64      `json:"finalizedEpoch"`
+	FinalizedBlockRoot common.Hash `json:"finalizedBlockRoot"`
+}
@@ -0,0 +1,96 @@
+// Chain simulator serves a simulated vanguard node over gRPC and a simulated pandora node over JSON-RPC,
+// producing mutually consistent chains, e.g. for devnets and integration tests of the orchestrator:
+//
+//	chain-simulator -vanguard-grpc-addr 127.0.0.1:4000 -pandora-rpc-addr 127.0.0.1:8546 -scenario reorg.json
+package main
+
+import (
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/tools/chain-simulator/simulator"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	vanguardAddr = flag.String(
+		"vanguard-grpc-addr",
+		"127.0.0.1:4000",
+		"Address the simulated vanguard node serves gRPC on",
+	)
+	pandoraAddr = flag.String(
+		"pandora-rpc-addr",
+		"127.0.0.1:8546",
+		"Address the simulated pandora node serves http and websocket JSON-RPC on",
+	)
+	slotDuration = flag.Duration(
+		"slot-duration",
+		6*time.Second,
+		"Time between two simulated slots",
+	)
+	slotsPerEpoch = flag.Uint64(
+		"slots-per-epoch",
+		32,
+		"Slots of a simulated epoch",
+	)
+	chainID = flag.Uint64(
+		"pandora-chain-id",
+		1,
+		"Chain id of the simulated pandora chain",
+	)
+	scenarioFile = flag.String(
+		"scenario",
+		"",
+		"Path to a json scenario script injecting reorgs, invalid shards, missing headers and disconnects",
+	)
+	verbosity = flag.String(
+		"verbosity",
+		"info",
+		"Logging verbosity (trace, debug, info, warn, error)",
+	)
+)
+
+func main() {
+	flag.Parse()
+	level, err := log.ParseLevel(*verbosity)
+	if err != nil {
+		log.WithError(err).Fatal("Invalid verbosity")
+	}
+	log.SetLevel(level)
+
+	var scenario *simulator.Scenario
+	if *scenarioFile != "" {
+		if scenario, err = simulator.LoadScenario(*scenarioFile); err != nil {
+			log.WithError(err).Fatal("Could not load scenario")
+		}
+	}
+
+	sim, err := simulator.New(&simulator.Config{
+		SlotDuration:  *slotDuration,
+		SlotsPerEpoch: *slotsPerEpoch,
+		ChainID:       *chainID,
+		Scenario:      scenario,
+	})
+	if err != nil {
+		log.WithError(err).Fatal("Could not create simulator")
+	}
+	grpcAddr, err := sim.ServeGRPC(*vanguardAddr)
+	if err != nil {
+		log.WithError(err).Fatal("Could not serve the simulated vanguard node")
+	}
+	rpcAddr, err := sim.ServeRPC(*pandoraAddr)
+	if err != nil {
+		log.WithError(err).Fatal("Could not serve the simulated pandora node")
+	}
+	sim.Start()
+	log.WithField("vanguard", grpcAddr).WithField("pandora", rpcAddr).Info("Serving simulated chains")
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	<-sigc
+	log.Info("Got interrupt, shutting down...")
+	sim.Stop()
+}
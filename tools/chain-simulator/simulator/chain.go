@@ -0,0 +1,404 @@
+package simulator
+
+import (
+	"encoding/binary"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bls/herumi"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const defaultFinalityDelay = 2
+
+func init() {
+	herumi.HerumiInit()
+}
+
+// Config of the simulated chains.
+type Config struct {
+	SlotDuration  time.Duration // time between two produced slots, 0 produces slots on ProduceSlot only
+	SlotsPerEpoch uint64        // slots, thus validators, of an epoch, 32 when not set
+	FinalityDelay uint64        // epochs between the head and the finalized epoch, 2 when not set
+	GenesisTime   uint64        // unix time of the genesis, the start of the simulator when not set
+	ChainID       uint64        // pandora chain id, 1 when not set
+	Scenario      *Scenario     // injected events, none when not set
+}
+
+// simulatedSlot is a slot of the canonical chain.
+type simulatedSlot struct {
+	block   *ethpb.StreamPendingBlockInfo // nil for the genesis
+	root    [32]byte
+	header  *eth1Types.Header
+	missing bool // the header is not streamed
+}
+
+// Chain generates a vanguard chain and the pandora chain sharded into it, one block and one header per slot.
+// Every pandora header carries the slot, epoch and proposer of its vanguard block in its extra data, signed
+// by the proposer, and the vanguard block carries the matching pandora shard. Proposers hold BLS keys derived
+// from their epoch and index, so the signatures of the header seal hashes are real and reproducible.
+type Chain struct {
+	cfg Config
+
+	lock      sync.RWMutex
+	canonical []*simulatedSlot // indexed by slot
+	epochs    []*ethpb.MinimalConsensusInfo
+	keys      [][]*bls.SecretKey                // indexed by epoch, then by proposer index
+	headers   map[common.Hash]*eth1Types.Header // all produced headers, forks included
+	forks     uint64                            // number of reorgs, makes the blocks of a fork differ
+
+	// everything produced in order, followed by the open streams
+	blockLog         []*ethpb.StreamPendingBlockInfo
+	consensusInfoLog []*ethpb.MinimalConsensusInfo
+	headerLog        []*simulatedSlot
+	changed          chan struct{} // closed when something was produced
+	disconnect       chan struct{} // closed when the vanguard streams must break
+}
+
+// NewChain creates chains holding the genesis only. The scenario is validated first.
+func NewChain(cfg *Config) (*Chain, error) {
+	if cfg.Scenario != nil {
+		if err := cfg.Scenario.validate(); err != nil {
+			return nil, err
+		}
+	}
+	c := &Chain{
+		cfg:        *cfg,
+		headers:    make(map[common.Hash]*eth1Types.Header),
+		changed:    make(chan struct{}),
+		disconnect: make(chan struct{}),
+	}
+	if c.cfg.SlotsPerEpoch == 0 {
+		c.cfg.SlotsPerEpoch = types.SlotsPerEpoch
+	}
+	if c.cfg.FinalityDelay == 0 {
+		c.cfg.FinalityDelay = defaultFinalityDelay
+	}
+	if c.cfg.GenesisTime == 0 {
+		c.cfg.GenesisTime = uint64(time.Now().Unix())
+	}
+	if c.cfg.ChainID == 0 {
+		c.cfg.ChainID = 1
+	}
+
+	genesis := &eth1Types.Header{
+		ParentHash:  common.Hash{},
+		UncleHash:   eth1Types.EmptyUncleHash,
+		Root:        eth1Types.EmptyRootHash,
+		TxHash:      eth1Types.EmptyRootHash,
+		ReceiptHash: eth1Types.EmptyRootHash,
+		Difficulty:  big.NewInt(1),
+		Number:      big.NewInt(0),
+		GasLimit:    8000000,
+		Time:        c.cfg.GenesisTime,
+		Extra:       []byte{},
+	}
+	c.canonical = []*simulatedSlot{{header: genesis}}
+	c.headers[genesis.Hash()] = genesis
+	if err := c.addEpoch(0); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// slotSeconds returns the slot duration announced by the consensus infos, in whole seconds.
+func (c *Chain) slotSeconds() uint64 {
+	if seconds := uint64(c.cfg.SlotDuration / time.Second); seconds > 0 {
+		return seconds
+	}
+	return 1
+}
+
+// HeadSlot returns the slot of the latest block of the canonical chain.
+func (c *Chain) HeadSlot() uint64 {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return uint64(len(c.canonical) - 1)
+}
+
+// Header returns the canonical pandora header of the given slot, nil when not produced.
+func (c *Chain) Header(slot uint64) *eth1Types.Header {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if slot >= uint64(len(c.canonical)) {
+		return nil
+	}
+	return c.canonical[slot].header
+}
+
+// Block returns the canonical vanguard block of the given slot, nil when not produced.
+func (c *Chain) Block(slot uint64) *ethpb.StreamPendingBlockInfo {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if slot >= uint64(len(c.canonical)) {
+		return nil
+	}
+	return c.canonical[slot].block
+}
+
+// ProduceSlot applies the scenario events of the next slot and produces it.
+func (c *Chain) ProduceSlot() (uint64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	slot := uint64(len(c.canonical))
+	var invalidShard, missingHeader bool
+	for _, event := range c.cfg.Scenario.at(slot) {
+		switch event.Kind {
+		case Reorg:
+			if err := c.reorg(slot, event.Depth); err != nil {
+				return slot, err
+			}
+		case InvalidShard:
+			invalidShard = true
+		case MissingHeader:
+			missingHeader = true
+		case Disconnect:
+			log.WithField("slot", slot).Info("Breaking vanguard streams")
+			close(c.disconnect)
+			c.disconnect = make(chan struct{})
+		}
+	}
+
+	if err := c.produce(slot, invalidShard, missingHeader); err != nil {
+		return slot, err
+	}
+	c.notify()
+	return slot, nil
+}
+
+// reorg replaces the given number of slots before the given one by a fork, unless it reverts finalized slots.
+func (c *Chain) reorg(slot, depth uint64) error {
+	forkSlot := slot - depth
+	finalizedSlot, _ := c.finality(slot - 1)
+	if forkSlot <= finalizedSlot || forkSlot == 0 {
+		log.WithField("slot", slot).WithField("depth", depth).WithField("finalizedSlot", finalizedSlot).
+			Warn("Skipping reorg reverting finalized slots")
+		return nil
+	}
+
+	c.forks++
+	parent := c.canonical[forkSlot-1]
+	c.canonical = c.canonical[:forkSlot]
+	epoch := c.epochOf(slot - 1)
+	reorgInfo := &ethpb.MinimalConsensusInfo{
+		Epoch:            eth2Types.Epoch(epoch),
+		ValidatorList:    c.epochs[epoch].ValidatorList,
+		EpochTimeStart:   c.epochs[epoch].EpochTimeStart,
+		SlotTimeDuration: c.epochs[epoch].SlotTimeDuration,
+		ReorgInfo: &ethpb.Reorg{
+			VanParentHash: parent.root[:],
+			PanParentHash: parent.header.Hash().Bytes(),
+			NewSlot:       eth2Types.Slot(forkSlot),
+		},
+	}
+	c.consensusInfoLog = append(c.consensusInfoLog, reorgInfo)
+	log.WithField("slot", slot).WithField("forkSlot", forkSlot).Info("Reorging simulated chains")
+
+	for reorged := forkSlot; reorged < slot; reorged++ {
+		if err := c.produce(reorged, false, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// produce appends the block and the header of the given slot to the canonical chain.
+func (c *Chain) produce(slot uint64, invalidShard, missingHeader bool) error {
+	epoch := c.epochOf(slot)
+	if slot%c.cfg.SlotsPerEpoch == 0 && uint64(len(c.epochs)) <= epoch {
+		if err := c.addEpoch(epoch); err != nil {
+			return err
+		}
+	}
+	proposerIndex := slot % c.cfg.SlotsPerEpoch
+	parent := c.canonical[slot-1]
+
+	header, sealHash, signature := c.newHeader(slot, epoch, proposerIndex, parent.header, c.keys[epoch][proposerIndex])
+	shard := &ethpb.PandoraShard{
+		BlockNumber: header.Number.Uint64(),
+		Hash:        header.Hash().Bytes(),
+		ParentHash:  header.ParentHash.Bytes(),
+		StateRoot:   header.Root.Bytes(),
+		TxHash:      header.TxHash.Bytes(),
+		ReceiptHash: header.ReceiptHash.Bytes(),
+		SealHash:    sealHash.Bytes(),
+		Signature:   signature,
+	}
+	if invalidShard {
+		log.WithField("slot", slot).Info("Producing invalid vanguard shard")
+		shard.StateRoot = crypto.Keccak256(shard.StateRoot)
+	}
+
+	finalizedSlot, finalizedEpoch := c.finality(slot)
+	block := c.newBlock(slot, proposerIndex, parent.root, shard)
+	root, err := block.HashTreeRoot()
+	if err != nil {
+		log.WithError(err).WithField("slot", slot).Error("Could not compute the simulated block root")
+		return errors.Wrap(err, "could not compute the block root")
+	}
+	produced := &simulatedSlot{
+		block: &ethpb.StreamPendingBlockInfo{
+			Block:          block,
+			FinalizedSlot:  eth2Types.Slot(finalizedSlot),
+			FinalizedEpoch: eth2Types.Epoch(finalizedEpoch),
+		},
+		root:    root,
+		header:  header,
+		missing: missingHeader,
+	}
+	if missingHeader {
+		log.WithField("slot", slot).Info("Withholding pandora header")
+	}
+
+	c.canonical = append(c.canonical, produced)
+	c.headers[header.Hash()] = header
+	c.blockLog = append(c.blockLog, produced.block)
+	c.headerLog = append(c.headerLog, produced)
+	return nil
+}
+
+// addEpoch creates the proposer keys and the consensus info of an epoch and logs it.
+func (c *Chain) addEpoch(epoch uint64) error {
+	keys := make([]*bls.SecretKey, c.cfg.SlotsPerEpoch)
+	validators := make([]string, c.cfg.SlotsPerEpoch)
+	for i := range validators {
+		keys[i] = new(bls.SecretKey)
+		if err := keys[i].SetLittleEndianMod(expand(32, uint64Bytes(epoch), uint64Bytes(uint64(i)))); err != nil {
+			return errors.Wrap(err, "could not derive the proposer key")
+		}
+		validators[i] = hexutil.Encode(keys[i].GetPublicKey().Serialize())
+	}
+	info := &ethpb.MinimalConsensusInfo{
+		Epoch:            eth2Types.Epoch(epoch),
+		ValidatorList:    validators,
+		EpochTimeStart:   c.cfg.GenesisTime + epoch*c.cfg.SlotsPerEpoch*c.slotSeconds(),
+		SlotTimeDuration: &durationpb.Duration{Seconds: int64(c.slotSeconds())},
+	}
+	c.keys = append(c.keys, keys)
+	c.epochs = append(c.epochs, info)
+	c.consensusInfoLog = append(c.consensusInfoLog, info)
+	return nil
+}
+
+// newHeader creates the pandora header of a slot along with its seal hash and the signature of its proposer.
+func (c *Chain) newHeader(
+	slot, epoch, proposerIndex uint64,
+	parent *eth1Types.Header,
+	proposer *bls.SecretKey,
+) (*eth1Types.Header, common.Hash, []byte) {
+	extraData := types.ExtraData{Slot: slot, Epoch: epoch, ProposerIndex: proposerIndex}
+	header := &eth1Types.Header{
+		ParentHash:  parent.Hash(),
+		UncleHash:   eth1Types.EmptyUncleHash,
+		Coinbase:    common.BytesToAddress(uint64Bytes(c.forks)),
+		Root:        common.BytesToHash(crypto.Keccak256(uint64Bytes(slot), uint64Bytes(c.forks))),
+		TxHash:      eth1Types.EmptyRootHash,
+		ReceiptHash: eth1Types.EmptyRootHash,
+		Difficulty:  big.NewInt(1),
+		Number:      new(big.Int).Add(parent.Number, big.NewInt(1)),
+		GasLimit:    parent.GasLimit,
+		Time:        c.cfg.GenesisTime + slot*c.slotSeconds(),
+	}
+	// the proposer signs the header carrying its unsigned extra data
+	header.Extra, _ = rlp.EncodeToBytes(&extraData)
+	sealHash := sealHash(header)
+	signature := proposer.SignByte(sealHash.Bytes()).Serialize()
+
+	var blsSignature types.BlsSignatureBytes
+	copy(blsSignature[:], signature)
+	header.Extra, _ = rlp.EncodeToBytes(&types.PanExtraDataWithBLSSig{ExtraData: extraData, BlsSignatureBytes: blsSignature})
+	return header, sealHash, signature
+}
+
+// newBlock creates the vanguard block of a slot.
+func (c *Chain) newBlock(slot, proposerIndex uint64, parentRoot [32]byte, shard *ethpb.PandoraShard) *ethpb.BeaconBlock {
+	return &ethpb.BeaconBlock{
+		Slot:          eth2Types.Slot(slot),
+		ProposerIndex: eth2Types.ValidatorIndex(proposerIndex),
+		ParentRoot:    parentRoot[:],
+		StateRoot:     crypto.Keccak256(parentRoot[:], uint64Bytes(slot)),
+		Body: &ethpb.BeaconBlockBody{
+			RandaoReveal: make([]byte, 96),
+			Eth1Data: &ethpb.Eth1Data{
+				DepositRoot: make([]byte, 32),
+				BlockHash:   make([]byte, 32),
+			},
+			Graffiti:          expand(32, []byte("chain-simulator"), uint64Bytes(c.forks)),
+			ProposerSlashings: []*ethpb.ProposerSlashing{},
+			AttesterSlashings: []*ethpb.AttesterSlashing{},
+			Attestations:      []*ethpb.Attestation{},
+			Deposits:          []*ethpb.Deposit{},
+			VoluntaryExits:    []*ethpb.SignedVoluntaryExit{},
+			PandoraShard:      []*ethpb.PandoraShard{shard},
+		},
+	}
+}
+
+// finality returns the finalized slot and epoch when the given slot is the head.
+func (c *Chain) finality(slot uint64) (uint64, uint64) {
+	epoch := c.epochOf(slot)
+	if epoch < c.cfg.FinalityDelay {
+		return 0, 0
+	}
+	finalizedEpoch := epoch - c.cfg.FinalityDelay
+	return finalizedEpoch * c.cfg.SlotsPerEpoch, finalizedEpoch
+}
+
+func (c *Chain) epochOf(slot uint64) uint64 {
+	return slot / c.cfg.SlotsPerEpoch
+}
+
+// notify wakes up the open streams. The caller holds the lock.
+func (c *Chain) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// sealHash returns the hash of a header prior to it being sealed.
+func sealHash(header *eth1Types.Header) (hash common.Hash) {
+	hasher := crypto.NewKeccakState()
+	rlp.Encode(hasher, []interface{}{
+		header.ParentHash,
+		header.UncleHash,
+		header.Coinbase,
+		header.Root,
+		header.TxHash,
+		header.ReceiptHash,
+		header.Bloom,
+		header.Difficulty,
+		header.Number,
+		header.GasLimit,
+		header.GasUsed,
+		header.Time,
+		header.Extra,
+	})
+	hasher.Read(hash[:])
+	return hash
+}
+
+// expand derives size deterministic bytes from the given seeds.
+func expand(size int, seeds ...[]byte) []byte {
+	out := make([]byte, 0, size+32)
+	for counter := uint64(0); len(out) < size; counter++ {
+		out = append(out, crypto.Keccak256(append(seeds, uint64Bytes(counter))...)...)
+	}
+	return out[:size]
+}
+
+func uint64Bytes(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b
+}
@@ -0,0 +1,106 @@
+package simulator
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/consensus"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// produceSlots produces the given number of slots on the chain.
+func produceSlots(t *testing.T, c *Chain, slots int) {
+	for i := 0; i < slots; i++ {
+		_, err := c.ProduceSlot()
+		require.NoError(t, err)
+	}
+}
+
+func TestChain_Consistency(t *testing.T) {
+	c, err := NewChain(&Config{GenesisTime: 1624266000})
+	require.NoError(t, err)
+	produceSlots(t, c, 70)
+	assert.Equal(t, uint64(70), c.HeadSlot())
+	assert.Equal(t, 3, len(c.epochs))
+
+	for slot := uint64(1); slot <= 70; slot++ {
+		header, blockInfo := c.Header(slot), c.Block(slot)
+		assert.Equal(t, true, consensus.CompareShardingInfo(header, blockInfo.Block.Body.PandoraShard[0]), "slot %d", slot)
+		assert.Equal(t, c.Header(slot-1).Hash(), header.ParentHash)
+		assert.DeepEqual(t, c.canonical[slot-1].root[:], blockInfo.Block.ParentRoot)
+
+		extraData := new(types.PanExtraDataWithBLSSig)
+		require.NoError(t, rlp.DecodeBytes(header.Extra, extraData))
+		assert.Equal(t, slot, extraData.Slot)
+		assert.Equal(t, slot/32, extraData.Epoch)
+
+		// the shard signature is the proposer's signature of the seal hash, verifiable by its published key
+		shard := blockInfo.Block.Body.PandoraShard[0]
+		pubKeyBytes, err := hexutil.Decode(c.epochs[extraData.Epoch].ValidatorList[extraData.ProposerIndex])
+		require.NoError(t, err)
+		var pubKey bls.PublicKey
+		require.NoError(t, pubKey.Deserialize(pubKeyBytes))
+		var signature bls.Sign
+		require.NoError(t, signature.Deserialize(shard.Signature))
+		assert.Equal(t, true, signature.VerifyByte(&pubKey, shard.SealHash), "slot %d", slot)
+		assert.DeepEqual(t, shard.Signature, extraData.BlsSignatureBytes[:])
+	}
+	// finality trails the head by two epochs
+	assert.Equal(t, uint64(0), uint64(c.Block(70).FinalizedSlot))
+	finalizedSlot, finalizedEpoch := c.finality(100)
+	assert.Equal(t, uint64(32), finalizedSlot)
+	assert.Equal(t, uint64(1), finalizedEpoch)
+}
+
+func TestChain_Scenario(t *testing.T) {
+	c, err := NewChain(&Config{GenesisTime: 1624266000, Scenario: &Scenario{Events: []*Event{
+		{Slot: 5, Kind: InvalidShard},
+		{Slot: 6, Kind: MissingHeader},
+		{Slot: 10, Kind: Reorg, Depth: 3},
+		// reverts the finalized slot 32
+		{Slot: 100, Kind: Reorg, Depth: 70},
+	}}})
+	require.NoError(t, err)
+	produceSlots(t, c, 9)
+	reorgedHeader := c.Header(7)
+
+	assert.Equal(t, false, consensus.CompareShardingInfo(c.Header(5), c.Block(5).Block.Body.PandoraShard[0]))
+	assert.Equal(t, true, c.canonical[6].missing)
+	headers := streamedHeaders(c.canonical[1:])
+	assert.Equal(t, 8, len(headers))
+	assert.Equal(t, uint64(7), headers[5].Number.Uint64())
+
+	// the reorg replaces the slots 7 to 9 and announces the fork
+	produceSlots(t, c, 1)
+	assert.Equal(t, uint64(10), c.HeadSlot())
+	assert.NotEqual(t, reorgedHeader.Hash(), c.Header(7).Hash())
+	assert.Equal(t, c.Header(6).Hash(), c.Header(7).ParentHash)
+	assert.Equal(t, true, consensus.CompareShardingInfo(c.Header(7), c.Block(7).Block.Body.PandoraShard[0]))
+	reorgInfo := c.consensusInfoLog[len(c.consensusInfoLog)-1].ReorgInfo
+	require.NotNil(t, reorgInfo)
+	assert.Equal(t, uint64(7), uint64(reorgInfo.NewSlot))
+	assert.DeepEqual(t, c.Header(6).Hash().Bytes(), reorgInfo.PanParentHash)
+	// reorged and new blocks are streamed in order
+	assert.Equal(t, 13, len(c.blockLog))
+	assert.Equal(t, uint64(10), uint64(c.blockLog[12].Block.Slot))
+
+	produceSlots(t, c, int(100-c.HeadSlot()))
+	assert.Equal(t, 1, len(c.consensusInfoLog)-len(c.epochs), "finalized slots must not be reorged")
+}
+
+func TestNewChain_InvalidScenario(t *testing.T) {
+	// a reorg deeper than its slot would fork before the genesis
+	_, err := NewChain(&Config{Scenario: &Scenario{Events: []*Event{{Slot: 3, Kind: Reorg, Depth: 5}}}})
+	assert.ErrorContains(t, errInvalidEvent.Error(), err)
+}
+
+func TestScenario_Validate(t *testing.T) {
+	assert.NoError(t, (&Scenario{Events: []*Event{{Slot: 3, Kind: Reorg, Depth: 2}}}).validate())
+	assert.ErrorContains(t, errInvalidEvent.Error(), (&Scenario{Events: []*Event{{Slot: 3, Kind: Reorg, Depth: 3}}}).validate())
+	assert.ErrorContains(t, errInvalidEvent.Error(), (&Scenario{Events: []*Event{{Slot: 3, Kind: "fork"}}}).validate())
+	assert.ErrorContains(t, errInvalidEvent.Error(), (&Scenario{Events: []*Event{{Kind: Disconnect}}}).validate())
+}
@@ -0,0 +1,5 @@
+package simulator
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "chain-simulator")
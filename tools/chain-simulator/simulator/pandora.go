@@ -0,0 +1,119 @@
+package simulator
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// pandoraAPI serves the simulated pandora chain in the eth namespace.
+type pandoraAPI struct {
+	sim *Simulator
+}
+
+// ChainId returns the chain id of the simulated pandora chain.
+func (api *pandoraAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int).SetUint64(api.sim.chain.cfg.ChainID))
+}
+
+// GetBlockByNumber returns the canonical header of the given number.
+func (api *pandoraAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (*eth1Types.Header, error) {
+	c := api.sim.chain
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber {
+		return c.canonical[len(c.canonical)-1].header, nil
+	}
+	if number < 0 || int(number) >= len(c.canonical) {
+		return nil, nil
+	}
+	return c.canonical[number].header, nil
+}
+
+// GetBlockByHash returns the header of the given hash, withheld and reorged headers included.
+func (api *pandoraAPI) GetBlockByHash(ctx context.Context, hash common.Hash, fullTx bool) (*eth1Types.Header, error) {
+	c := api.sim.chain
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.headers[hash], nil
+}
+
+// GetPendingBlockHeaders returns the canonical headers following the header of the filter.
+func (api *pandoraAPI) GetPendingBlockHeaders(
+	ctx context.Context, filter types.PandoraPendingHeaderFilter,
+) ([]*eth1Types.Header, error) {
+	c := api.sim.chain
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return streamedHeaders(c.canonical[c.headersFrom(filter.FromBlockHash):]), nil
+}
+
+// NewPendingBlockHeaders streams the canonical headers following the header of the filter, followed by
+// every new header, including the headers of forks.
+func (api *pandoraAPI) NewPendingBlockHeaders(
+	ctx context.Context, filter types.PandoraPendingHeaderFilter,
+) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	subscription := notifier.CreateSubscription()
+
+	c := api.sim.chain
+	c.lock.RLock()
+	pending := streamedHeaders(c.canonical[c.headersFrom(filter.FromBlockHash):])
+	next, changed := len(c.headerLog), c.changed
+	c.lock.RUnlock()
+
+	go func() {
+		for {
+			for _, header := range pending {
+				if err := notifier.Notify(subscription.ID, header); err != nil {
+					log.WithError(err).Debug("Could not send simulated pandora header")
+					return
+				}
+			}
+			select {
+			case <-changed:
+			case <-subscription.Err():
+				return
+			case <-notifier.Closed():
+				return
+			case <-api.sim.stop:
+				return
+			}
+			c.lock.RLock()
+			pending = streamedHeaders(c.headerLog[next:])
+			next, changed = len(c.headerLog), c.changed
+			c.lock.RUnlock()
+		}
+	}()
+	return subscription, nil
+}
+
+// headersFrom returns the slot following the canonical header of the given hash, 1 when the hash is not
+// canonical. The caller holds the lock.
+func (c *Chain) headersFrom(hash common.Hash) int {
+	for slot, simulated := range c.canonical {
+		if simulated.header.Hash() == hash {
+			return slot + 1
+		}
+	}
+	return 1
+}
+
+// streamedHeaders returns the headers of the given slots which are not withheld.
+func streamedHeaders(slots []*simulatedSlot) []*eth1Types.Header {
+	headers := make([]*eth1Types.Header, 0, len(slots))
+	for _, simulated := range slots {
+		if !simulated.missing {
+			headers = append(headers, simulated.header)
+		}
+	}
+	return headers
+}
@@ -0,0 +1,83 @@
+package simulator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// EventKind is the kind of a scenario event.
+type EventKind string
+
+const (
+	Reorg         EventKind = "reorg"         // the Depth slots before the event slot are replaced by a fork
+	InvalidShard  EventKind = "invalidShard"  // the vanguard shard of the slot does not match the pandora header
+	MissingHeader EventKind = "missingHeader" // the pandora header of the slot is not streamed, only served on request
+	Disconnect    EventKind = "disconnect"    // the open vanguard streams break before the slot is produced
+)
+
+var errInvalidEvent = errors.New("invalid scenario event")
+
+// Event is injected into the simulated chains when the given slot is produced.
+type Event struct {
+	Slot  uint64    `json:"slot"`
+	Kind  EventKind `json:"kind"`
+	Depth uint64    `json:"depth,omitempty"` // reorged slots, reorgs only
+}
+
+// Scenario scripts the events of a simulation, e.g.
+//
+//	{"events": [{"slot": 40, "kind": "reorg", "depth": 3}, {"slot": 45, "kind": "missingHeader"}]}
+type Scenario struct {
+	Events []*Event `json:"events"`
+}
+
+// LoadScenario reads a json scenario script.
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read scenario")
+	}
+	scenario := new(Scenario)
+	if err := json.Unmarshal(data, scenario); err != nil {
+		return nil, errors.Wrap(err, "could not decode scenario")
+	}
+	if err := scenario.validate(); err != nil {
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// validate checks the kinds of the events and the depth of the reorgs.
+func (s *Scenario) validate() error {
+	for i, event := range s.Events {
+		switch event.Kind {
+		case Reorg:
+			if event.Depth == 0 || event.Depth >= event.Slot {
+				return errors.Wrapf(errInvalidEvent, "event %d reorgs %d slots at slot %d", i, event.Depth, event.Slot)
+			}
+		case InvalidShard, MissingHeader, Disconnect:
+		default:
+			return errors.Wrapf(errInvalidEvent, "event %d has unknown kind %q", i, event.Kind)
+		}
+		if event.Slot == 0 {
+			return errors.Wrapf(errInvalidEvent, "event %d targets the genesis slot", i)
+		}
+	}
+	return nil
+}
+
+// at returns the events of the given slot.
+func (s *Scenario) at(slot uint64) []*Event {
+	if s == nil {
+		return nil
+	}
+	var events []*Event
+	for _, event := range s.Events {
+		if event.Slot == slot {
+			events = append(events, event)
+		}
+	}
+	return events
+}
@@ -0,0 +1,157 @@
+// Package simulator simulates a vanguard node and a pandora node producing mutually consistent chains, so the
+// orchestrator can be run and tested without real clients. Scenario scripts inject reorgs, invalid shards,
+// withheld headers and broken streams at given slots.
+package simulator
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Simulator produces the simulated chains and serves them the way a vanguard node serves gRPC and a
+// pandora node serves JSON-RPC.
+type Simulator struct {
+	chain      *Chain
+	grpcServer *grpc.Server
+	rpcServer  *rpc.Server
+	httpServer *http.Server
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// New creates a simulator of chains holding the genesis only.
+func New(cfg *Config) (*Simulator, error) {
+	chain, err := NewChain(cfg)
+	if err != nil {
+		return nil, err
+	}
+	s := &Simulator{
+		chain: chain,
+		stop:  make(chan struct{}),
+	}
+	s.rpcServer = rpc.NewServer()
+	if err := s.rpcServer.RegisterName("eth", &pandoraAPI{sim: s}); err != nil {
+		return nil, err
+	}
+	s.grpcServer = grpc.NewServer()
+	ethpb.RegisterBeaconChainServer(s.grpcServer, &beaconChainServer{sim: s})
+	ethpb.RegisterNodeServer(s.grpcServer, &nodeServer{sim: s})
+	return s, nil
+}
+
+// Chain returns the simulated chains.
+func (s *Simulator) Chain() *Chain {
+	return s.chain
+}
+
+// Start produces a slot every slot duration until the simulator stops. Without slot duration, slots are
+// only produced by Chain().ProduceSlot.
+func (s *Simulator) Start() {
+	if s.chain.cfg.SlotDuration <= 0 {
+		return
+	}
+	log.WithField("slotDuration", s.chain.cfg.SlotDuration).WithField("genesisTime", s.chain.cfg.GenesisTime).
+		Info("Producing simulated slots")
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.chain.cfg.SlotDuration)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				slot, err := s.chain.ProduceSlot()
+				if err != nil {
+					log.WithError(err).WithField("slot", slot).Error("Could not produce simulated slot")
+					continue
+				}
+				log.WithField("slot", slot).Debug("Produced simulated slot")
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// ServeGRPC serves the simulated vanguard node on the given address, e.g. 127.0.0.1:4000, and returns the
+// address it listens on.
+func (s *Simulator) ServeGRPC(address string) (string, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", errors.Wrap(err, "could not listen for the simulated vanguard node")
+	}
+	go func() {
+		if err := s.grpcServer.Serve(listener); err != nil {
+			log.WithError(err).Debug("Stopped serving the simulated vanguard node")
+		}
+	}()
+	return listener.Addr().String(), nil
+}
+
+// ServeRPC serves the simulated pandora node over http and websocket on the given address, e.g.
+// 127.0.0.1:8546, and returns the address it listens on.
+func (s *Simulator) ServeRPC(address string) (string, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", errors.Wrap(err, "could not listen for the simulated pandora node")
+	}
+	wsHandler := s.rpcServer.WebsocketHandler([]string{"*"})
+	s.httpServer = &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") == "websocket" {
+			wsHandler.ServeHTTP(w, r)
+			return
+		}
+		s.rpcServer.ServeHTTP(w, r)
+	})}
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.WithError(err).Error("Stopped serving the simulated pandora node")
+		}
+	}()
+	return listener.Addr().String(), nil
+}
+
+// RPCServer returns the JSON-RPC server of the simulated pandora node, e.g. for rpc.DialInProc.
+func (s *Simulator) RPCServer() *rpc.Server {
+	return s.rpcServer
+}
+
+// Stop stops producing slots and serving.
+func (s *Simulator) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		s.wg.Wait()
+		s.grpcServer.Stop()
+		if s.httpServer != nil {
+			s.httpServer.Close()
+		}
+		s.rpcServer.Stop()
+	})
+}
+
+// waitForChange blocks a vanguard stream until something is produced. It returns the error breaking the
+// stream when the scenario disconnects it or the simulator stops.
+func (s *Simulator) waitForChange(ctx context.Context, changed, disconnect <-chan struct{}) error {
+	select {
+	case <-changed:
+		return nil
+	case <-disconnect:
+		return status.Error(codes.Unavailable, "simulated disconnect")
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.stop:
+		return status.Error(codes.Unavailable, "simulator stopped")
+	}
+}
@@ -0,0 +1,140 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/consensus"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// startSimulator starts a simulator of the given scenario having produced the given number of slots.
+func startSimulator(t *testing.T, slots int, scenario *Scenario) (*Simulator, string) {
+	sim, err := New(&Config{GenesisTime: 1624266000, Scenario: scenario})
+	require.NoError(t, err)
+	t.Cleanup(sim.Stop)
+	produceSlots(t, sim.Chain(), slots)
+	address, err := sim.ServeGRPC("127.0.0.1:0")
+	require.NoError(t, err)
+	return sim, address
+}
+
+func TestSimulator_Streams(t *testing.T) {
+	sim, address := startSimulator(t, 3, &Scenario{Events: []*Event{
+		{Slot: 5, Kind: Disconnect},
+		{Slot: 5, Kind: MissingHeader},
+	}})
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	beaconClient := ethpb.NewBeaconChainClient(conn)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := beaconClient.StreamNewPendingBlocks(ctx, &ethpb.StreamPendingBlocksRequest{FromSlot: 2})
+	require.NoError(t, err)
+	client := rpc.DialInProc(sim.RPCServer())
+	defer client.Close()
+	headerCh := make(chan *eth1Types.Header, 10)
+	sub, err := client.Subscribe(ctx, "eth", headerCh, "newPendingBlockHeaders",
+		&types.PandoraPendingHeaderFilter{FromBlockHash: sim.Chain().Header(1).Hash()})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	// the backlog is followed by the produced slots until the scenario breaks the stream
+	produceSlots(t, sim.Chain(), 1)
+	for _, slot := range []uint64{2, 3, 4} {
+		blockInfo, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, slot, uint64(blockInfo.Block.Slot))
+	}
+	produceSlots(t, sim.Chain(), 1)
+	for {
+		if _, err = stream.Recv(); err != nil {
+			break
+		}
+	}
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// the withheld header is not streamed
+	produceSlots(t, sim.Chain(), 1)
+	var numbers []uint64
+	for len(numbers) < 4 {
+		select {
+		case header := <-headerCh:
+			numbers = append(numbers, header.Number.Uint64())
+		case <-time.After(time.Second):
+			t.Fatalf("Received headers %v", numbers)
+		}
+	}
+	assert.DeepEqual(t, []uint64{2, 3, 4, 6}, numbers)
+
+	chainHead, err := beaconClient.GetChainHead(ctx, &empty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), uint64(chainHead.HeadSlot))
+}
+
+func TestSimulator_Orchestrator(t *testing.T) {
+	sim, address := startSimulator(t, 2, &Scenario{Events: []*Event{{Slot: 4, Kind: InvalidShard}}})
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+
+	vanguardSvc, err := vanguardchain.NewService(ctx, []string{address}, nil, db, cache.NewVanShardInfoCache(1024), nil)
+	require.NoError(t, err)
+	dialInProc := func(endpoint string) (*rpc.Client, error) {
+		return rpc.DialInProc(sim.RPCServer()), nil
+	}
+	pandoraSvc, err := pandorachain.NewService(ctx, []string{"ws://simulator"}, "eth", db,
+		cache.NewPanHeaderCache(), dialInProc, 0, nil)
+	require.NoError(t, err)
+	consensusSvc := consensus.New(ctx, &consensus.Config{
+		VerifiedSlotInfoDB:           db,
+		InvalidSlotInfoDB:            db,
+		VanguardPendingShardingCache: cache.NewVanShardInfoCache(1024),
+		PandoraPendingHeaderCache:    cache.NewPanHeaderCache(),
+		VanguardShardFeed:            vanguardSvc,
+		PandoraHeaderFeed:            pandoraSvc,
+	})
+	slotInfoCh := make(chan *types.SlotInfoWithStatus, 10)
+	sub := consensusSvc.SubscribeVerifiedSlotInfoEvent(slotInfoCh)
+	defer sub.Unsubscribe()
+
+	consensusSvc.Start()
+	defer consensusSvc.Stop()
+	vanguardSvc.Start()
+	defer vanguardSvc.Stop()
+	pandoraSvc.Start()
+	defer pandoraSvc.Stop()
+
+	// the simulated slots are verified by the orchestrator, the invalid shard is detected
+	statuses := make(map[types.Status]int)
+	produceSlots(t, sim.Chain(), 3)
+	for statuses[types.Verified]+statuses[types.Invalid] < 5 {
+		select {
+		case slotInfo := <-slotInfoCh:
+			statuses[slotInfo.Status]++
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Received statuses %v", statuses)
+		}
+	}
+	assert.Equal(t, 4, statuses[types.Verified])
+	assert.Equal(t, 1, statuses[types.Invalid])
+	assert.Equal(t, uint64(5), db.LatestSavedVerifiedSlot())
+	invalid, err := db.InvalidSlotInfo(4)
+	require.NoError(t, err)
+	assert.Equal(t, sim.Chain().Header(4).Hash(), invalid.PandoraHeaderHash)
+}
@@ -0,0 +1,147 @@
+package simulator
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// beaconChainServer serves the simulated vanguard chain.
+type beaconChainServer struct {
+	ethpb.UnimplementedBeaconChainServer
+	sim *Simulator
+}
+
+// StreamNewPendingBlocks streams the canonical blocks from the requested slot on, followed by every new
+// block, including the blocks of forks.
+func (b *beaconChainServer) StreamNewPendingBlocks(
+	req *ethpb.StreamPendingBlocksRequest,
+	stream ethpb.BeaconChain_StreamNewPendingBlocksServer,
+) error {
+	c := b.sim.chain
+	c.lock.RLock()
+	var pending []*ethpb.StreamPendingBlockInfo
+	for _, slot := range c.canonical[1:] {
+		if slot.block.Block.Slot >= req.FromSlot {
+			pending = append(pending, slot.block)
+		}
+	}
+	next, changed, disconnect := len(c.blockLog), c.changed, c.disconnect
+	c.lock.RUnlock()
+
+	for {
+		for _, blockInfo := range pending {
+			if err := stream.Send(blockInfo); err != nil {
+				return err
+			}
+		}
+		if err := b.sim.waitForChange(stream.Context(), changed, disconnect); err != nil {
+			return err
+		}
+		c.lock.RLock()
+		pending = c.blockLog[next:]
+		next, changed = len(c.blockLog), c.changed
+		c.lock.RUnlock()
+	}
+}
+
+// StreamMinimalConsensusInfo streams the consensus infos from the requested epoch on, followed by every new
+// consensus info, including the ones announcing reorgs.
+func (b *beaconChainServer) StreamMinimalConsensusInfo(
+	req *ethpb.MinimalConsensusInfoRequest,
+	stream ethpb.BeaconChain_StreamMinimalConsensusInfoServer,
+) error {
+	c := b.sim.chain
+	c.lock.RLock()
+	var pending []*ethpb.MinimalConsensusInfo
+	if uint64(req.FromEpoch) < uint64(len(c.epochs)) {
+		pending = c.epochs[req.FromEpoch:]
+	}
+	next, changed, disconnect := len(c.consensusInfoLog), c.changed, c.disconnect
+	c.lock.RUnlock()
+
+	for {
+		for _, consensusInfo := range pending {
+			if err := stream.Send(consensusInfo); err != nil {
+				return err
+			}
+		}
+		if err := b.sim.waitForChange(stream.Context(), changed, disconnect); err != nil {
+			return err
+		}
+		c.lock.RLock()
+		pending = c.consensusInfoLog[next:]
+		next, changed = len(c.consensusInfoLog), c.changed
+		c.lock.RUnlock()
+	}
+}
+
+// GetChainHead returns the head of the canonical chain and its checkpoints.
+func (b *beaconChainServer) GetChainHead(context.Context, *empty.Empty) (*ethpb.ChainHead, error) {
+	c := b.sim.chain
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	headSlot := uint64(len(c.canonical) - 1)
+	finalizedSlot, finalizedEpoch := c.finality(headSlot)
+	justifiedEpoch := finalizedEpoch
+	if finalizedEpoch+1 < c.epochOf(headSlot) {
+		justifiedEpoch++
+	}
+	justifiedSlot := justifiedEpoch * c.cfg.SlotsPerEpoch
+	return &ethpb.ChainHead{
+		HeadSlot:           eth2Types.Slot(headSlot),
+		HeadEpoch:          eth2Types.Epoch(c.epochOf(headSlot)),
+		HeadBlockRoot:      c.canonical[headSlot].root[:],
+		FinalizedSlot:      eth2Types.Slot(finalizedSlot),
+		FinalizedEpoch:     eth2Types.Epoch(finalizedEpoch),
+		FinalizedBlockRoot: c.canonical[finalizedSlot].root[:],
+		JustifiedSlot:      eth2Types.Slot(justifiedSlot),
+		JustifiedEpoch:     eth2Types.Epoch(justifiedEpoch),
+		JustifiedBlockRoot: c.canonical[justifiedSlot].root[:],
+	}, nil
+}
+
+// ListBlocks returns the canonical block of the requested slot. Only the slot filter is supported.
+func (b *beaconChainServer) ListBlocks(ctx context.Context, req *ethpb.ListBlocksRequest) (*ethpb.ListBlocksResponse, error) {
+	filter, ok := req.QueryFilter.(*ethpb.ListBlocksRequest_Slot)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "simulator only lists blocks by slot")
+	}
+	c := b.sim.chain
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	res := new(ethpb.ListBlocksResponse)
+	slot := uint64(filter.Slot)
+	if slot == 0 || slot >= uint64(len(c.canonical)) {
+		return res, nil
+	}
+	simulated := c.canonical[slot]
+	res.BlockContainers = []*ethpb.BeaconBlockContainer{{
+		Block:     &ethpb.SignedBeaconBlock{Block: simulated.block.Block, Signature: make([]byte, 96)},
+		BlockRoot: simulated.root[:],
+		Canonical: true,
+	}}
+	res.TotalSize = 1
+	return res, nil
+}
+
+// nodeServer serves the genesis of the simulated vanguard chain.
+type nodeServer struct {
+	ethpb.UnimplementedNodeServer
+	sim *Simulator
+}
+
+// GetGenesis returns the genesis time of the simulated chains.
+func (n *nodeServer) GetGenesis(context.Context, *empty.Empty) (*ethpb.Genesis, error) {
+	return &ethpb.Genesis{
+		GenesisTime:           &timestamppb.Timestamp{Seconds: int64(n.sim.chain.cfg.GenesisTime)},
+		GenesisValidatorsRoot: make([]byte, 32),
+	}, nil
+}